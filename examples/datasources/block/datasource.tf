@@ -13,6 +13,15 @@ data "mageai_block" "default" {
   uuid          = "daring_butterfly"
 }
 
+data "mageai_block" "by_name" {
+  pipeline_uuid = "example_pipeline"
+  name          = "Load Orders"
+}
+
 output "default_pipeline_block" {
   value = data.mageai_block.default
 }
+
+output "load_orders_uuid" {
+  value = data.mageai_block.by_name.uuid
+}
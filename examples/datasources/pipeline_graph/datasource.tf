@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    mageai = {
+      source = "komminarlabs/mageai"
+    }
+  }
+}
+
+provider "mageai" {}
+
+data "mageai_pipeline_graph" "default" {
+  pipeline_uuid = "example_pipeline"
+}
+
+output "default_pipeline_mermaid" {
+  value = data.mageai_pipeline_graph.default.mermaid
+}
+
+output "default_pipeline_topological_order" {
+  value = data.mageai_pipeline_graph.default.topological_order
+}
@@ -0,0 +1,19 @@
+terraform {
+  required_providers {
+    mageai = {
+      source = "komminarlabs/mageai"
+    }
+  }
+}
+
+provider "mageai" {}
+
+data "mageai_pipeline_runs" "default" {
+  pipeline_uuid = "example_pipeline"
+  status        = "failed"
+  created_after = "2024-09-01T00:00:00Z"
+}
+
+output "default_pipeline_runs" {
+  value = data.mageai_pipeline_runs.default
+}
@@ -10,6 +10,17 @@ provider "mageai" {}
 
 data "mageai_pipelines" "all" {}
 
+data "mageai_pipelines" "active_sales" {
+  type            = "python"
+  tags            = ["sales"]
+  status          = "active"
+  include_content = false
+}
+
 output "pipelines" {
   value = data.mageai_pipelines.all
 }
+
+output "active_sales_pipelines" {
+  value = data.mageai_pipelines.active_sales.pipelines[*].uuid
+}
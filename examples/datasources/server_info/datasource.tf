@@ -0,0 +1,15 @@
+terraform {
+  required_providers {
+    mageai = {
+      source = "komminarlabs/mageai"
+    }
+  }
+}
+
+provider "mageai" {}
+
+data "mageai_server_info" "default" {}
+
+output "default_server_version" {
+  value = data.mageai_server_info.default.version
+}
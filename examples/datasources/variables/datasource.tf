@@ -0,0 +1,17 @@
+terraform {
+  required_providers {
+    mageai = {
+      source = "komminarlabs/mageai"
+    }
+  }
+}
+
+provider "mageai" {}
+
+data "mageai_variables" "default" {
+  pipeline_uuid = "example_pipeline"
+}
+
+output "default_pipeline_variables" {
+  value = data.mageai_variables.default
+}
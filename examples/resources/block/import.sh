@@ -0,0 +1,2 @@
+# A block is imported using the pipeline UUID and the block UUID separated by a slash.
+terraform import mageai_block.default example_pipeline/example_block
@@ -19,6 +19,14 @@ resource "mageai_block" "default" {
   }
 }
 
+resource "mageai_block" "transformer" {
+  name            = "example_transformer"
+  pipeline_uuid   = "example_pipeline"
+  type            = "transformer"
+  content_file    = "${path.module}/script.py"
+  upstream_blocks = [mageai_block.default.uuid]
+}
+
 output "default_block" {
   value = mageai_block.default
 }
@@ -0,0 +1,5 @@
+# A profile of io_config.yaml is imported using its name.
+terraform import mageai_io_config_profile.warehouse warehouse
+
+# A profile of another file is imported using the path of the file and the name of the profile separated by a slash.
+terraform import mageai_io_config_profile.warehouse sales/io_config.yaml/warehouse
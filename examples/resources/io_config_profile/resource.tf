@@ -0,0 +1,49 @@
+terraform {
+  required_providers {
+    mageai = {
+      source = "komminarlabs/mageai"
+    }
+  }
+}
+
+provider "mageai" {}
+
+variable "snowflake_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "mageai_io_config_profile" "warehouse" {
+  name = "warehouse"
+
+  postgres = {
+    host     = "db.example.com"
+    port     = "5432"
+    database = "analytics"
+    user     = "mage"
+    password = "{{ mage_secret_var('postgres_password') }}"
+  }
+
+  snowflake = {
+    account   = "myorg-account"
+    user      = "mage"
+    password  = var.snowflake_password
+    warehouse = "COMPUTE_WH"
+  }
+
+  extra_yaml = <<-EOT
+    POSTGRES_CONNECTION_METHOD: direct
+  EOT
+}
+
+resource "mageai_block" "orders" {
+  name          = "load_orders"
+  pipeline_uuid = "example_pipeline"
+  type          = "data_loader"
+  language      = "sql"
+
+  configuration = {
+    data_provider         = "postgres"
+    data_provider_profile = mageai_io_config_profile.warehouse.name
+  }
+}
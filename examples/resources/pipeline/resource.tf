@@ -9,8 +9,17 @@ terraform {
 provider "mageai" {}
 
 resource "mageai_pipeline" "default" {
-  name = "example_pipeline"
-  type = "python"
+  name        = "example_pipeline"
+  type        = "python"
+  description = "Loads the example data."
+  tags        = ["example"]
+
+  retry_config = {
+    delay               = 5
+    exponential_backoff = true
+    max_delay           = 60
+    retries             = 3
+  }
 }
 
 output "default_pipeline" {
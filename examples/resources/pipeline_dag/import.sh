@@ -0,0 +1,2 @@
+# A pipeline DAG is imported using the pipeline UUID. All the blocks of the pipeline are imported.
+terraform import mageai_pipeline_dag.default example_pipeline
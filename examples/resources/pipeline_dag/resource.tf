@@ -0,0 +1,46 @@
+terraform {
+  required_providers {
+    mageai = {
+      source = "komminarlabs/mageai"
+    }
+  }
+}
+
+provider "mageai" {}
+
+resource "mageai_pipeline_dag" "default" {
+  pipeline_uuid = "example_pipeline"
+
+  blocks = {
+    load_orders = {
+      type    = "data_loader"
+      content = <<-EOT
+        @data_loader
+        def load_orders(*args, **kwargs):
+            return [{"id": 1, "amount": 10}, {"id": 2, "amount": None}]
+      EOT
+    }
+    clean_orders = {
+      type            = "transformer"
+      upstream_blocks = ["load_orders"]
+      content         = <<-EOT
+        @transformer
+        def clean_orders(orders, *args, **kwargs):
+            return [order for order in orders if order["amount"] is not None]
+      EOT
+    }
+    export_orders = {
+      type            = "data_exporter"
+      upstream_blocks = ["clean_orders"]
+      content         = <<-EOT
+        @data_exporter
+        def export_orders(orders, *args, **kwargs):
+            print(orders)
+      EOT
+    }
+  }
+}
+
+output "default_pipeline_dag" {
+  value = mageai_pipeline_dag.default
+}
@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    mageai = {
+      source = "komminarlabs/mageai"
+    }
+  }
+}
+
+provider "mageai" {}
+
+resource "mageai_pipeline_run" "default" {
+  pipeline_schedule_id = 1
+  wait_for_completion  = true
+
+  variables = {
+    execution_date = "2024-09-01"
+  }
+
+  triggers = {
+    version = "1.0.0"
+  }
+
+  timeouts = {
+    create = "1h"
+  }
+}
+
+output "default_pipeline_run" {
+  value = mageai_pipeline_run.default
+}
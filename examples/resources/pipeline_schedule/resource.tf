@@ -0,0 +1,31 @@
+terraform {
+  required_providers {
+    mageai = {
+      source = "komminarlabs/mageai"
+    }
+  }
+}
+
+provider "mageai" {}
+
+resource "mageai_pipeline_schedule" "default" {
+  name              = "example_schedule"
+  pipeline_uuid     = "example_pipeline"
+  schedule_type     = "time"
+  schedule_interval = "@daily"
+  start_time        = "2024-09-01 00:00:00"
+  status            = "active"
+
+  variables = {
+    environment = "production"
+  }
+
+  settings = {
+    skip_if_previous_running = true
+    timeout                  = 3600
+  }
+}
+
+output "default_pipeline_schedule" {
+  value = mageai_pipeline_schedule.default
+}
@@ -0,0 +1,2 @@
+# A secret is imported using its name. Its value is set in state on the next apply.
+terraform import mageai_secret.postgres_password postgres_password
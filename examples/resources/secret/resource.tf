@@ -0,0 +1,19 @@
+terraform {
+  required_providers {
+    mageai = {
+      source = "komminarlabs/mageai"
+    }
+  }
+}
+
+provider "mageai" {}
+
+variable "postgres_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "mageai_secret" "postgres_password" {
+  name  = "postgres_password"
+  value = var.postgres_password
+}
@@ -0,0 +1,2 @@
+# A variable is imported using the pipeline UUID and the variable name separated by a slash.
+terraform import mageai_variable.environment example_pipeline/environment
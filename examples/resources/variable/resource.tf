@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    mageai = {
+      source = "komminarlabs/mageai"
+    }
+  }
+}
+
+provider "mageai" {}
+
+resource "mageai_variable" "environment" {
+  pipeline_uuid = "example_pipeline"
+  name          = "environment"
+  value         = jsonencode("production")
+}
+
+resource "mageai_variable" "source" {
+  pipeline_uuid = "example_pipeline"
+  name          = "source"
+  value = jsonencode({
+    schema = "public"
+    tables = ["orders", "customers"]
+  })
+}
+
+output "environment_variable" {
+  value = mageai_variable.environment
+}
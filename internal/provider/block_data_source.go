@@ -3,17 +3,25 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ datasource.DataSource              = &BlockDataSource{}
-	_ datasource.DataSourceWithConfigure = &BlockDataSource{}
+	_ datasource.DataSource                     = &BlockDataSource{}
+	_ datasource.DataSourceWithConfigure        = &BlockDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &BlockDataSource{}
 )
 
 // NewBlockDataSource is a helper function to simplify the provider implementation.
@@ -35,7 +43,7 @@ func (d *BlockDataSource) Metadata(ctx context.Context, req datasource.MetadataR
 func (d *BlockDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		Description: "Fetch and return the contents of a block in a pipeline.",
+		Description: "Fetch and return the contents of a block in a pipeline, by its `uuid`, or by its `name`, `type` or `language`. The lookup fails when no block or several blocks of the pipeline match.",
 		Attributes: map[string]schema.Attribute{
 			"pipeline_uuid": schema.StringAttribute{
 				Required:    true,
@@ -102,16 +110,19 @@ func (d *BlockDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 			},
 			"language": schema.StringAttribute{
 				Computed:    true,
-				Description: "The language.",
+				Optional:    true,
+				Description: "The language. When set, only blocks of this language are matched.",
 			},
 			"name": schema.StringAttribute{
 				Computed:    true,
-				Description: "Human readable name of block.",
+				Optional:    true,
+				Description: "Human readable name of block. When set, only the block with this exact name is matched.",
 			},
 			"priority": schema.Int32Attribute{
 				Computed:    true,
 				Description: "The priority.",
 			},
+			"project": projectDataSourceAttribute(),
 			"retry_config": schema.SingleNestedAttribute{
 				Computed:    true,
 				Description: "The blocks objects of a block.",
@@ -134,6 +145,10 @@ func (d *BlockDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 					},
 				},
 			},
+			"slug": schema.StringAttribute{
+				Computed:    true,
+				Description: "The UUID Mage AI derives from the `name` of the block. It differs from `uuid` for blocks stored in subdirectories, such as dbt blocks.",
+			},
 			"status": schema.StringAttribute{
 				Computed:    true,
 				Description: "Status of block: `executed`, `failed`, `not_executed`, `updated`.",
@@ -144,7 +159,11 @@ func (d *BlockDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 			},
 			"type": schema.StringAttribute{
 				Computed:    true,
-				Description: "Type of block: `callback`, `chart`, `conditional`, `custom`, `data_exporter`, `data_loader`, `dbt`, `extension`, `global_data_product`, `markdown`, `scratchpad`, `sensor`, `transformer`.",
+				Optional:    true,
+				Description: "Type of block: `callback`, `chart`, `conditional`, `custom`, `data_exporter`, `data_loader`, `dbt`, `extension`, `global_data_product`, `markdown`, `scratchpad`, `sensor`, `transformer`. When set, only blocks of this type are matched.",
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"callback", "chart", "conditional", "custom", "data_exporter", "data_loader", "dbt", "extension", "global_data_product", "markdown", "scratchpad", "sensor", "transformer"}...),
+				},
 			},
 			"upstream_blocks": schema.SetAttribute{
 				Computed:    true,
@@ -152,13 +171,30 @@ func (d *BlockDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				ElementType: types.StringType,
 			},
 			"uuid": schema.StringAttribute{
-				Required:    true,
-				Description: "Unique identifier for the block.",
+				Computed:    true,
+				Optional:    true,
+				Description: "Unique identifier for the block. Conflicts with `name`, `type` and `language`.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("name"), path.MatchRoot("type"), path.MatchRoot("language")),
+				},
 			},
 		},
 	}
 }
 
+// ConfigValidators requires the block to be looked up by UUID or by at least
+// one of its name, type or language.
+func (d *BlockDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.AtLeastOneOf(
+			path.MatchRoot("uuid"),
+			path.MatchRoot("name"),
+			path.MatchRoot("type"),
+			path.MatchRoot("language"),
+		),
+	}
+}
+
 // Configure adds the provider configured client to the data source.
 func (d *BlockDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
@@ -187,17 +223,14 @@ func (d *BlockDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	readDatabaseResponse, err := d.client.BlockAPI().ReadBlock(ctx, state.PipelineUUID.ValueStringPointer(), state.UUID.ValueStringPointer())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error getting block",
-			err.Error(),
-		)
+	block, diags := d.lookupBlock(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Map response body to model
-	blockState, err := getBlockModel(ctx, readDatabaseResponse.Block)
+	blockState, err := getBlockModel(ctx, *block)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error getting blocks",
@@ -206,11 +239,81 @@ func (d *BlockDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 	state.BlockModel = *blockState
+	state.Slug = types.StringValue(mageai.CleanName(block.Name))
 
 	// Set state
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
+
+// lookupBlock returns the block with the UUID of the config, or else the only
+// block of the pipeline that matches the name, type and language of the
+// config.
+func (d *BlockDataSource) lookupBlock(ctx context.Context, config BlockDataSourceModel) (*mageai.Block, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	client := projectClient(d.client, config.Project)
+
+	if !config.UUID.IsNull() {
+		readBlockResponse, err := client.BlockAPI().ReadBlock(ctx, config.PipelineUUID.ValueStringPointer(), config.UUID.ValueStringPointer())
+		if err != nil {
+			diags.Append(newClientErrorDiagnostic(
+				"Error getting block",
+				"",
+				err,
+			))
+			return nil, diags
+		}
+		return &readBlockResponse.Block, diags
+	}
+
+	readBlocksResponse, err := client.BlockAPI().ReadBlocks(ctx, config.PipelineUUID.ValueStringPointer())
+	if err != nil {
+		diags.Append(newClientErrorDiagnostic(
+			"Error getting blocks",
+			"",
+			err,
+		))
+		return nil, diags
+	}
+
+	criteria := []string{}
+	for attribute, value := range map[string]types.String{"language": config.Language, "name": config.Name, "type": config.Type} {
+		if !value.IsNull() {
+			criteria = append(criteria, fmt.Sprintf("%s %q", attribute, value.ValueString()))
+		}
+	}
+	slices.Sort(criteria)
+
+	matches := []mageai.Block{}
+	matchedUUIDs := []string{}
+	for _, block := range readBlocksResponse.Blocks {
+		if !config.Name.IsNull() && block.Name != config.Name.ValueString() ||
+			!config.Type.IsNull() && block.Type != config.Type.ValueString() ||
+			!config.Language.IsNull() && block.Language != config.Language.ValueString() {
+			continue
+		}
+		matches = append(matches, block)
+		matchedUUIDs = append(matchedUUIDs, block.UUID)
+	}
+
+	switch len(matches) {
+	case 0:
+		diags.AddError(
+			"Block Not Found",
+			fmt.Sprintf("No block of pipeline %q has the %s.", config.PipelineUUID.ValueString(), strings.Join(criteria, ", ")),
+		)
+		return nil, diags
+	case 1:
+		return &matches[0], diags
+	}
+
+	diags.AddError(
+		"Multiple Blocks Found",
+		fmt.Sprintf("%d blocks of pipeline %q have the %s: %s. Set more of name, type and language, or set uuid, to select one of them.",
+			len(matches), config.PipelineUUID.ValueString(), strings.Join(criteria, ", "), strings.Join(matchedUUIDs, ", ")),
+	)
+	return nil, diags
+}
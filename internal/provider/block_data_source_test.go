@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func TestAccBlockDataSource(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccBlockResourceConfig + `
+data "mageai_block" "test" {
+  pipeline_uuid = mageai_block.test.pipeline_uuid
+  uuid          = mageai_block.test.uuid
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mageai_block.test", "name", "example_block"),
+					resource.TestCheckResourceAttr("data.mageai_block.test", "type", "data_loader"),
+					resource.TestCheckResourceAttr("data.mageai_block.test", "language", "python"),
+					resource.TestCheckResourceAttr("data.mageai_block.test", "content", "print('hello')"),
+					resource.TestCheckResourceAttr("data.mageai_block.test", "configuration.data_provider", "postgres"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBlockDataSourceNotFound(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "mageai_pipeline" "test" {
+  name = "example_pipeline"
+}
+
+data "mageai_block" "test" {
+  pipeline_uuid = mageai_pipeline.test.uuid
+  uuid          = "missing_block"
+}
+`,
+				ExpectError: regexp.MustCompile(`Block missing_block does not exist`),
+			},
+		},
+	})
+}
+
+func TestAccBlockDataSourceLookup(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	blocksConfig := `
+resource "mageai_pipeline" "test" {
+  name = "example_pipeline"
+}
+
+resource "mageai_block" "loader" {
+  name          = "Load Orders"
+  pipeline_uuid = mageai_pipeline.test.uuid
+  type          = "data_loader"
+  content       = "print('load')"
+}
+
+resource "mageai_block" "transformer" {
+  name            = "Clean Orders"
+  pipeline_uuid   = mageai_pipeline.test.uuid
+  type            = "transformer"
+  content         = "print('clean')"
+  upstream_blocks = [mageai_block.loader.uuid]
+}
+
+resource "mageai_block" "query" {
+  name            = "Aggregate Orders"
+  pipeline_uuid   = mageai_pipeline.test.uuid
+  type            = "transformer"
+  language        = "sql"
+  content         = "SELECT 1"
+  upstream_blocks = [mageai_block.transformer.uuid]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "mageai_block" "test" {
+  pipeline_uuid = "example_pipeline"
+}
+`,
+				ExpectError: regexp.MustCompile(`At\s+least\s+one\s+of\s+these\s+attributes\s+must\s+be\s+configured`),
+			},
+			{
+				Config: testAccProviderConfig(server) + blocksConfig + `
+data "mageai_block" "by_name" {
+  pipeline_uuid = mageai_pipeline.test.uuid
+  name          = "Load Orders"
+  depends_on    = [mageai_block.loader]
+}
+
+data "mageai_block" "by_type_and_language" {
+  pipeline_uuid = mageai_pipeline.test.uuid
+  type          = "transformer"
+  language      = "sql"
+  depends_on    = [mageai_block.query]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mageai_block.by_name", "uuid", "load_orders"),
+					resource.TestCheckResourceAttr("data.mageai_block.by_name", "slug", "load_orders"),
+					resource.TestCheckResourceAttr("data.mageai_block.by_name", "type", "data_loader"),
+					resource.TestCheckResourceAttr("data.mageai_block.by_name", "content", "print('load')"),
+					resource.TestCheckResourceAttr("data.mageai_block.by_type_and_language", "uuid", "aggregate_orders"),
+					resource.TestCheckResourceAttr("data.mageai_block.by_type_and_language", "name", "Aggregate Orders"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(server) + blocksConfig + `
+data "mageai_block" "test" {
+  pipeline_uuid = mageai_pipeline.test.uuid
+  type          = "transformer"
+  depends_on    = [mageai_block.query]
+}
+`,
+				ExpectError: regexp.MustCompile(`2\s+blocks\s+of\s+pipeline\s+"example_pipeline"\s+have\s+the\s+type\s+"transformer":\s+clean_orders,\s+aggregate_orders`),
+			},
+			{
+				Config: testAccProviderConfig(server) + blocksConfig + `
+data "mageai_block" "test" {
+  pipeline_uuid = mageai_pipeline.test.uuid
+  name          = "load_orders"
+  type          = "data_loader"
+  depends_on    = [mageai_block.loader]
+}
+`,
+				ExpectError: regexp.MustCompile(`No\s+block\s+of\s+pipeline\s+"example_pipeline"\s+has\s+the\s+name\s+"load_orders",\s+type\s+"data_loader"`),
+			},
+		},
+	})
+}
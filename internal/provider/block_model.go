@@ -2,7 +2,11 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -12,16 +16,23 @@ import (
 
 type BlockDataSourceModel struct {
 	PipelineUUID types.String `tfsdk:"pipeline_uuid"`
+	Project      types.String `tfsdk:"project"`
+	Slug         types.String `tfsdk:"slug"`
 	BlockModel
 }
 
 type BlockResourceModel struct {
-	PipelineUUID types.String `tfsdk:"pipeline_uuid"`
+	ContentFile   types.String `tfsdk:"content_file"`
+	ContentSHA256 types.String `tfsdk:"content_sha256"`
+	PipelineUUID  types.String `tfsdk:"pipeline_uuid"`
+	Project       types.String `tfsdk:"project"`
+	Slug          types.String `tfsdk:"slug"`
 	BlockModel
 }
 
 type BlocksDataSourceModel struct {
 	PipelineUUID types.String `tfsdk:"pipeline_uuid"`
+	Project      types.String `tfsdk:"project"`
 	Blocks       []BlockModel `tfsdk:"blocks"`
 }
 
@@ -146,17 +157,72 @@ func getBlockModel(ctx context.Context, block mageai.Block) (*BlockModel, error)
 	return &blockState, nil
 }
 
+// setBlockResourceContent sets the content attributes of the resource model
+// from the content of the block. The content is not stored when it is read
+// from content_file, and the prior content is kept when it only differs from
+// the block content by whitespace that Mage AI does not preserve.
+func setBlockResourceContent(model *BlockResourceModel, priorContent types.String, block mageai.Block) {
+	model.ContentSHA256 = types.StringValue(blockContentSHA256(block.Content))
+
+	switch {
+	case !model.ContentFile.IsNull():
+		model.Content = types.StringNull()
+	case !priorContent.IsNull() && !priorContent.IsUnknown() && normalizeBlockContent(priorContent.ValueString()) == normalizeBlockContent(block.Content):
+		model.Content = priorContent
+	default:
+		model.Content = types.StringValue(block.Content)
+	}
+}
+
+// normalizeBlockContent returns the content without carriage returns, trailing
+// whitespace on every line, and trailing empty lines, which Mage AI does not
+// preserve when it saves the block file.
+func normalizeBlockContent(content string) string {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// blockContentSHA256 returns the hex-encoded SHA-256 hash of the normalized
+// content.
+func blockContentSHA256(content string) string {
+	sum := sha256.Sum256([]byte(normalizeBlockContent(content)))
+	return hex.EncodeToString(sum[:])
+}
+
+// getBlockResourceContent returns the content to send to Mage AI, read from
+// content_file when it is set.
+func getBlockResourceContent(b BlockResourceModel) (string, error) {
+	if b.ContentFile.IsNull() {
+		return b.Content.ValueString(), nil
+	}
+
+	content, err := os.ReadFile(b.ContentFile.ValueString())
+	if err != nil {
+		return "", fmt.Errorf("error reading content_file: %w", err)
+	}
+	return string(content), nil
+}
+
 func convertUpstreamBlocksSetToStringSlice(upstreamBlocks basetypes.SetValue) []string {
 	upstreamBlocksSlice := []string{}
 	for _, block := range upstreamBlocks.Elements() {
-		upstreamBlocksSlice = append(upstreamBlocksSlice, block.String())
+		if block, ok := block.(types.String); ok {
+			upstreamBlocksSlice = append(upstreamBlocksSlice, block.ValueString())
+		}
 	}
 	return upstreamBlocksSlice
 }
 
 func convertBlockConfigurationObjectToModel(ctx context.Context, blockConfigurationObject basetypes.ObjectValue) (*mageai.BlockConfiguration, error) {
+	if blockConfigurationObject.IsNull() || blockConfigurationObject.IsUnknown() {
+		return &mageai.BlockConfiguration{}, nil
+	}
+
 	configurationModel := BlockConfigurationModel{}
-	diags := blockConfigurationObject.As(ctx, &configurationModel, basetypes.ObjectAsOptions{})
+	diags := blockConfigurationObject.As(ctx, &configurationModel, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})
 	if diags.HasError() {
 		return nil, fmt.Errorf("could not get configuration, unexpected error: %v", diags.Errors())
 	}
@@ -181,10 +247,15 @@ func makeCreateBlockRequestFromModel(ctx context.Context, b BlockResourceModel)
 		return nil, fmt.Errorf("error converting block configuration: %v", err)
 	}
 
+	content, err := getBlockResourceContent(b)
+	if err != nil {
+		return nil, err
+	}
+
 	return &mageai.CreateBlockRequest{
 		Block: mageai.BlockRequest{
 			Configuration:  *configuration,
-			Content:        b.Content.ValueString(),
+			Content:        content,
 			ExtensionUUID:  b.ExtensionUUID.ValueString(),
 			Language:       b.Language.ValueString(),
 			Name:           b.Name.ValueString(),
@@ -201,10 +272,15 @@ func makeUpdateBlockRequestFromModel(ctx context.Context, b BlockResourceModel)
 		return nil, fmt.Errorf("error converting block configuration")
 	}
 
+	content, err := getBlockResourceContent(b)
+	if err != nil {
+		return nil, err
+	}
+
 	return &mageai.UpdateBlockRequest{
 		Block: mageai.BlockRequest{
 			Configuration:  *configuration,
-			Content:        b.Content.ValueString(),
+			Content:        content,
 			ExtensionUUID:  b.ExtensionUUID.ValueString(),
 			Language:       b.Language.ValueString(),
 			Name:           b.Name.ValueString(),
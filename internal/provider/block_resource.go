@@ -3,24 +3,29 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ resource.Resource                = &BlockResource{}
-	_ resource.ResourceWithImportState = &BlockResource{}
-	_ resource.ResourceWithImportState = &BlockResource{}
+	_ resource.Resource                   = &BlockResource{}
+	_ resource.ResourceWithConfigure      = &BlockResource{}
+	_ resource.ResourceWithImportState    = &BlockResource{}
+	_ resource.ResourceWithModifyPlan     = &BlockResource{}
+	_ resource.ResourceWithValidateConfig = &BlockResource{}
 )
 
 // NewBlockResource is a helper function to simplify the provider implementation.
@@ -30,7 +35,8 @@ func NewBlockResource() resource.Resource {
 
 // BlockResource defines the resource implementation.
 type BlockResource struct {
-	client mageai.Client
+	client             mageai.Client
+	serverVersionCheck string
 }
 
 // Metadata returns the resource type name.
@@ -118,11 +124,27 @@ func (r *BlockResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"content": schema.StringAttribute{
 				Computed:    true,
 				Optional:    true,
-				Description: "Block file contents.",
+				Description: "Block file contents. Conflicts with `content_file`.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					useNullWhenConfigured(path.Root("content_file")),
+					requiresReplaceIfBlockContentChanged(),
 					stringplanmodifier.UseStateForUnknown(),
 				},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("content_file")),
+				},
+			},
+			"content_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to the file with the block contents, e.g. `${path.module}/blocks/load_data.py`. The contents are not stored in the state, only their `content_sha256`, so that the plan shows a change of hash instead of the whole file. Conflicts with `content`.",
+			},
+			"content_sha256": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA-256 hash of the block contents, with the trailing whitespace of the lines and the trailing empty lines removed. Only changes of the code change the hash, and replace the block.",
+				PlanModifiers: []planmodifier.String{
+					useBlockContentSHA256(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"downstream_blocks": schema.SetAttribute{
 				Computed:    true,
@@ -156,6 +178,7 @@ func (r *BlockResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:    true,
 				Description: "The priority.",
 			},
+			"project": projectResourceAttribute(),
 			"retry_config": schema.SingleNestedAttribute{
 				Computed:    true,
 				Description: "The blocks objects of a block.",
@@ -178,6 +201,13 @@ func (r *BlockResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					},
 				},
 			},
+			"slug": schema.StringAttribute{
+				Computed:    true,
+				Description: "The UUID Mage AI derives from the `name` of the block. Unlike `uuid`, it is known before the block is created, e.g. to reference the block in the configuration of other resources. It differs from `uuid` for blocks stored in subdirectories, such as dbt blocks. Reference `uuid` rather than `slug` in `upstream_blocks`, so that the upstream block is created first.",
+				PlanModifiers: []planmodifier.String{
+					useBlockSlug(),
+				},
+			},
 			"status": schema.StringAttribute{
 				Computed:    true,
 				Description: "Status of block: `executed`, `failed`, `not_executed`, `updated`.",
@@ -195,9 +225,12 @@ func (r *BlockResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			},
 			"upstream_blocks": schema.SetAttribute{
 				Computed:    true,
-				Description: "The block UUIDs that this block depends on.",
-				Default:     setdefault.StaticValue(types.SetUnknown(types.StringType)),
+				Optional:    true,
+				Description: "The block UUIDs that this block depends on. The plan fails when an upstream block does not exist in the pipeline, depends on this block, or cannot be an upstream block of this block, e.g. a data exporter of a data loader.",
 				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"uuid": schema.StringAttribute{
 				Computed:    true,
@@ -210,6 +243,92 @@ func (r *BlockResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	}
 }
 
+// ValidateConfig validates the upstream blocks that can be checked without the
+// blocks of the pipeline.
+func (r *BlockResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config BlockResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Type.IsUnknown() || config.UpstreamBlocks.IsNull() || config.UpstreamBlocks.IsUnknown() || len(config.UpstreamBlocks.Elements()) == 0 {
+		return
+	}
+
+	if blockType := config.Type.ValueString(); slices.Contains(unlinkedBlockTypes, blockType) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("upstream_blocks"),
+			"Invalid Upstream Block",
+			fmt.Sprintf("Invalid upstream blocks of block %q: %s.", config.Name.ValueString(), illegalUpstreamBlock("", blockType)),
+		)
+	}
+}
+
+// ModifyPlan checks that the Mage AI server supports the type of the block,
+// and validates the upstream blocks against the blocks of the pipeline, so
+// that missing blocks, cycles and illegal dependencies fail at plan time
+// rather than when the pipeline runs. Upstream blocks that are not known yet,
+// e.g. created in the same apply, are not validated.
+func (r *BlockResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Do nothing if the resource is being destroyed or the provider is not configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan BlockResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := projectClient(r.client, plan.Project)
+	if requirement, ok := blockTypeRequirement(plan.Type.ValueString()); ok {
+		resp.Diagnostics.Append(checkServerVersion(ctx, client, r.serverVersionCheck, []serverRequirement{requirement})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if plan.PipelineUUID.IsUnknown() || plan.Type.IsUnknown() || plan.UpstreamBlocks.IsNull() || plan.UpstreamBlocks.IsUnknown() {
+		return
+	}
+
+	upstreamBlocks := []string{}
+	for _, upstreamBlock := range plan.UpstreamBlocks.Elements() {
+		if upstreamBlock, ok := upstreamBlock.(types.String); ok && !upstreamBlock.IsUnknown() && !upstreamBlock.IsNull() {
+			upstreamBlocks = append(upstreamBlocks, upstreamBlock.ValueString())
+		}
+	}
+
+	if len(upstreamBlocks) == 0 {
+		return
+	}
+
+	pipelineBlocks := []mageai.Block{}
+	readBlocksResponse, err := client.BlockAPI().ReadBlocks(ctx, plan.PipelineUUID.ValueStringPointer())
+	switch {
+	case err == nil:
+		pipelineBlocks = readBlocksResponse.Blocks
+	case !mageai.IsNotFound(err):
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error validating upstream blocks",
+			"Could not get the blocks of the pipeline, unexpected error: ",
+			err,
+		))
+		return
+	}
+
+	// The UUID of a new block is only known once it is created
+	blockUUID := ""
+	if !plan.UUID.IsUnknown() {
+		blockUUID = plan.UUID.ValueString()
+	}
+
+	resp.Diagnostics.Append(validateUpstreamBlocks(path.Root("upstream_blocks"), blockUUID, plan.Type.ValueString(), upstreamBlocks, pipelineBlocks)...)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *BlockResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan BlockResourceModel
@@ -230,12 +349,15 @@ func (r *BlockResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	createBlockResponse, err := r.client.BlockAPI().CreateBlock(ctx, plan.PipelineUUID.ValueStringPointer(), createBlockRequest)
+	client := projectClient(r.client, plan.Project)
+	plan.Project = projectValue(client)
+	createBlockResponse, err := client.BlockAPI().CreateBlock(ctx, plan.PipelineUUID.ValueStringPointer(), createBlockRequest)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
 			"Error creating block",
-			"Could not create block, unexpected error: "+err.Error(),
-		)
+			"Could not create block, unexpected error: ",
+			err,
+		))
 		return
 	}
 
@@ -248,7 +370,10 @@ func (r *BlockResource) Create(ctx context.Context, req resource.CreateRequest,
 		)
 		return
 	}
+	priorContent := plan.Content
 	plan.BlockModel = *blockModel
+	setBlockResourceContent(&plan, priorContent, createBlockResponse.Block)
+	plan.Slug = types.StringValue(mageai.CleanName(plan.Name.ValueString()))
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -269,12 +394,21 @@ func (r *BlockResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Get refreshed block value from Mage AI
-	readDatabaseResponse, err := r.client.BlockAPI().ReadBlock(ctx, state.PipelineUUID.ValueStringPointer(), state.UUID.ValueStringPointer())
+	client := projectClient(r.client, state.Project)
+	readDatabaseResponse, err := client.BlockAPI().ReadBlock(ctx, state.PipelineUUID.ValueStringPointer(), state.UUID.ValueStringPointer())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		// Remove the resource from state so that Terraform plans to recreate it
+		if mageai.IsNotFound(err) {
+			tflog.Warn(ctx, "The block no longer exists, removing it from state", map[string]any{"pipeline_uuid": state.PipelineUUID.ValueString(), "uuid": state.UUID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
 			"Error getting block",
-			err.Error(),
-		)
+			"",
+			err,
+		))
 		return
 	}
 
@@ -287,7 +421,10 @@ func (r *BlockResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		)
 		return
 	}
+	priorContent := state.Content
 	state.BlockModel = *blockState
+	setBlockResourceContent(&state, priorContent, readDatabaseResponse.Block)
+	state.Slug = types.StringValue(mageai.CleanName(state.Name.ValueString()))
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -317,12 +454,14 @@ func (r *BlockResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Update existing block
-	updateBlockResponse, err := r.client.BlockAPI().UpdateBlock(ctx, plan.PipelineUUID.ValueStringPointer(), plan.UUID.ValueStringPointer(), updateBlockRequest)
+	client := projectClient(r.client, plan.Project)
+	updateBlockResponse, err := client.BlockAPI().UpdateBlock(ctx, plan.PipelineUUID.ValueStringPointer(), plan.UUID.ValueStringPointer(), updateBlockRequest)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
 			"Error updating block",
-			"Could not update block, unexpected error: "+err.Error(),
-		)
+			"Could not update block, unexpected error: ",
+			err,
+		))
 		return
 	}
 
@@ -335,7 +474,10 @@ func (r *BlockResource) Update(ctx context.Context, req resource.UpdateRequest,
 		)
 		return
 	}
+	priorContent := plan.Content
 	plan.BlockModel = *blockModel
+	setBlockResourceContent(&plan, priorContent, updateBlockResponse.Block)
+	plan.Slug = types.StringValue(mageai.CleanName(plan.Name.ValueString()))
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -355,12 +497,19 @@ func (r *BlockResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 
 	// Delete existing block
-	err := r.client.BlockAPI().DeleteBlock(ctx, state.PipelineUUID.ValueStringPointer(), state.UUID.ValueStringPointer())
+	client := projectClient(r.client, state.Project)
+	err := client.BlockAPI().DeleteBlock(ctx, state.PipelineUUID.ValueStringPointer(), state.UUID.ValueStringPointer())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		// The block is already gone, there is nothing left to delete
+		if mageai.IsNotFound(err) {
+			return
+		}
+
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
 			"Error deleting block",
-			"Could not delete block, unexpected error: "+err.Error(),
-		)
+			"Could not delete block, unexpected error: ",
+			err,
+		))
 		return
 	}
 }
@@ -381,8 +530,23 @@ func (r *BlockResource) Configure(ctx context.Context, req resource.ConfigureReq
 		return
 	}
 	r.client = pd.client
+	r.serverVersionCheck = pd.serverVersionCheck
 }
 
+// ImportState imports a block using an identifier of the form
+// pipeline_uuid/block_uuid. Only the first slash is a separator since block
+// UUIDs, e.g. of dbt blocks, can contain slashes.
 func (r *BlockResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+	pipelineUUID, blockUUID, ok := strings.Cut(req.ID, "/")
+	if !ok || pipelineUUID == "" || blockUUID == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: pipeline_uuid/block_uuid. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("pipeline_uuid"), pipelineUUID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uuid"), blockUUID)...)
+	importProject(ctx, r.client, resp)
 }
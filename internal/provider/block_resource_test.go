@@ -0,0 +1,549 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	frameworkresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func TestAccBlockResourceImport(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccBlockResourceConfig,
+			},
+			{
+				ResourceName:                         "mageai_block.test",
+				ImportState:                          true,
+				ImportStateId:                        "example_pipeline/example_block",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "uuid",
+			},
+			{
+				ResourceName:    "mageai_block.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   "example_pipeline/example_block",
+			},
+			{
+				ResourceName:  "mageai_block.test",
+				ImportState:   true,
+				ImportStateId: "example_block",
+				ExpectError:   regexp.MustCompile(`Expected import identifier with format: pipeline_uuid/block_uuid`),
+			},
+		},
+	})
+}
+
+const testAccBlockResourceConfig = `
+resource "mageai_pipeline" "test" {
+  name = "example_pipeline"
+}
+
+resource "mageai_block" "test" {
+  name          = "example_block"
+  pipeline_uuid = mageai_pipeline.test.uuid
+  type          = "data_loader"
+  content       = "print('hello')"
+
+  configuration = {
+    data_provider = "postgres"
+  }
+}
+`
+
+func TestAccBlockResourceSlug(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "mageai_pipeline" "test" {
+  name = "example_pipeline"
+}
+
+resource "mageai_block" "test" {
+  name          = "1st Load - Orders (v2)"
+  pipeline_uuid = mageai_pipeline.test.uuid
+  type          = "data_loader"
+  content       = "print('hello')"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("mageai_block.test", tfjsonpath.New("slug"), knownvalue.StringExact("letter_1st_load___orders__v2_")),
+						plancheck.ExpectUnknownValue("mageai_block.test", tfjsonpath.New("uuid")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_block.test", "slug", "letter_1st_load___orders__v2_"),
+					resource.TestCheckResourceAttrPair("mageai_block.test", "slug", "mageai_block.test", "uuid"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBlockResourceUpstreamBlocks(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccBlockResourceUpstreamBlocksConfig("[mageai_block.loader.uuid]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_block.transformer", "upstream_blocks.#", "1"),
+					resource.TestCheckTypeSetElemAttr("mageai_block.transformer", "upstream_blocks.*", "load_data"),
+					resource.TestCheckResourceAttr("mageai_block.loader", "upstream_blocks.#", "0"),
+				),
+			},
+			{
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("mageai_block.loader", "downstream_blocks.*", "transform_data"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(server) + testAccBlockResourceUpstreamBlocksConfig("[]"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mageai_block.transformer", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_block.transformer", "upstream_blocks.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccBlockResourceUpstreamBlocksConfig(upstreamBlocks string) string {
+	return fmt.Sprintf(`
+resource "mageai_pipeline" "test" {
+  name = "example_pipeline"
+}
+
+resource "mageai_block" "loader" {
+  name          = "load_data"
+  pipeline_uuid = mageai_pipeline.test.uuid
+  type          = "data_loader"
+  content       = "print('load')"
+
+  configuration = {
+    data_provider = "postgres"
+  }
+}
+
+resource "mageai_block" "transformer" {
+  name            = "transform_data"
+  pipeline_uuid   = mageai_pipeline.test.uuid
+  type            = "transformer"
+  content         = "print('transform')"
+  upstream_blocks = %s
+}
+`, upstreamBlocks)
+}
+
+func TestBlockResourceImportState(t *testing.T) {
+	testCases := map[string]struct {
+		id                   string
+		expectedPipelineUUID string
+		expectedBlockUUID    string
+		expectError          bool
+	}{
+		"pipeline and block": {
+			id:                   "example_pipeline/example_block",
+			expectedPipelineUUID: "example_pipeline",
+			expectedBlockUUID:    "example_block",
+		},
+		"dbt block with slashes": {
+			id:                   "example_pipeline/dbt/models/example_model",
+			expectedPipelineUUID: "example_pipeline",
+			expectedBlockUUID:    "dbt/models/example_model",
+		},
+		"block only": {
+			id:          "example_block",
+			expectError: true,
+		},
+		"empty pipeline": {
+			id:          "/example_block",
+			expectError: true,
+		},
+		"empty block": {
+			id:          "example_pipeline/",
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &BlockResource{}
+
+			state := newTestResourceState(t, r, nil)
+			resp := &frameworkresource.ImportStateResponse{State: state}
+			r.ImportState(ctx, frameworkresource.ImportStateRequest{ID: testCase.id}, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("expected error: %t, got diagnostics: %v", testCase.expectError, resp.Diagnostics)
+			}
+
+			if testCase.expectError {
+				return
+			}
+
+			var pipelineUUID, blockUUID types.String
+			resp.State.GetAttribute(ctx, path.Root("pipeline_uuid"), &pipelineUUID)
+			resp.State.GetAttribute(ctx, path.Root("uuid"), &blockUUID)
+
+			if pipelineUUID.ValueString() != testCase.expectedPipelineUUID || blockUUID.ValueString() != testCase.expectedBlockUUID {
+				t.Errorf("expected %s/%s, got %s/%s", testCase.expectedPipelineUUID, testCase.expectedBlockUUID, pipelineUUID.ValueString(), blockUUID.ValueString())
+			}
+		})
+	}
+}
+
+func TestBlockResourceReadRemovesMissingBlock(t *testing.T) {
+	r := &BlockResource{client: newNotFoundTestClient(t)}
+	testResourceReadRemovesMissing(t, r, map[string]any{
+		"name":          "example_block",
+		"pipeline_uuid": "example_pipeline",
+		"uuid":          "example_block",
+	})
+}
+
+func TestAccBlockResourceContentFile(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	contentFile := filepath.Join(t.TempDir(), "load_data.py")
+	writeTestBlockContentFile(t, contentFile, "print('load')\n")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccProviderConfig(server) + testAccBlockResourceContentConfig(`content_file = "missing.py"`),
+				ExpectError: regexp.MustCompile(`Unable\s+to\s+Read\s+Block\s+Content\s+File`),
+			},
+			{
+				Config:      testAccProviderConfig(server) + testAccBlockResourceContentConfig(fmt.Sprintf("content = \"print('load')\"\n  content_file = %q", contentFile)),
+				ExpectError: regexp.MustCompile(`Invalid\s+Attribute\s+Combination`),
+			},
+			{
+				Config: testAccProviderConfig(server) + testAccBlockResourceContentConfig(fmt.Sprintf("content_file = %q", contentFile)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("mageai_block.test", "content"),
+					resource.TestCheckResourceAttr("mageai_block.test", "content_sha256", blockContentSHA256("print('load')")),
+					testAccCheckBlockContent(server, "print('load')\n"),
+				),
+			},
+			{
+				PreConfig: func() {
+					server.SetBlockContent("example_pipeline", "example_block", "print('load')  \r\n\n")
+				},
+				Config:   testAccProviderConfig(server) + testAccBlockResourceContentConfig(fmt.Sprintf("content_file = %q", contentFile)),
+				PlanOnly: true,
+			},
+			{
+				PreConfig: func() {
+					writeTestBlockContentFile(t, contentFile, "print('load data')\n")
+				},
+				Config: testAccProviderConfig(server) + testAccBlockResourceContentConfig(fmt.Sprintf("content_file = %q", contentFile)),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mageai_block.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_block.test", "content_sha256", blockContentSHA256("print('load data')")),
+					testAccCheckBlockContent(server, "print('load data')\n"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(server) + testAccBlockResourceContentConfig(`content = "print('load')"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_block.test", "content", "print('load')"),
+					resource.TestCheckResourceAttr("mageai_block.test", "content_sha256", blockContentSHA256("print('load')")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBlockResourceContentNormalized(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccBlockResourceContentConfig(`content = "print('load')"`),
+			},
+			{
+				PreConfig: func() {
+					server.SetBlockContent("example_pipeline", "example_block", "print('load') \n")
+				},
+				Config:   testAccProviderConfig(server) + testAccBlockResourceContentConfig(`content = "print('load')"`),
+				PlanOnly: true,
+			},
+			// Whitespace changes of the configuration update the block in place
+			{
+				Config: testAccProviderConfig(server) + testAccBlockResourceContentConfig(`content = "print('load')  \n\n"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mageai_block.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_block.test", "content", "print('load')  \n\n"),
+					resource.TestCheckResourceAttr("mageai_block.test", "content_sha256", blockContentSHA256("print('load')")),
+				),
+			},
+			{
+				PreConfig: func() {
+					server.SetBlockContent("example_pipeline", "example_block", "print('changed in the UI')\n")
+				},
+				Config: testAccProviderConfig(server) + testAccBlockResourceContentConfig(`content = "print('load')"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mageai_block.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: testAccCheckBlockContent(server, "print('load')"),
+			},
+		},
+	})
+}
+
+func testAccCheckBlockContent(server *mageaitest.Server, expectedContent string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		pipeline, ok := server.Pipeline("example_pipeline")
+		if !ok {
+			return fmt.Errorf("pipeline example_pipeline not found")
+		}
+
+		for _, block := range pipeline.Blocks {
+			if block.UUID == "example_block" {
+				if block.Content != expectedContent {
+					return fmt.Errorf("expected block content %q, got %q", expectedContent, block.Content)
+				}
+				return nil
+			}
+		}
+		return fmt.Errorf("block example_block not found")
+	}
+}
+
+func writeTestBlockContentFile(t *testing.T, name, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+		t.Fatalf("writing block content file: %v", err)
+	}
+}
+
+func testAccBlockResourceContentConfig(content string) string {
+	return fmt.Sprintf(`
+resource "mageai_pipeline" "test" {
+  name = "example_pipeline"
+}
+
+resource "mageai_block" "test" {
+  name          = "example_block"
+  pipeline_uuid = mageai_pipeline.test.uuid
+  type          = "data_loader"
+  %s
+}
+`, content)
+}
+
+func TestNormalizeBlockContent(t *testing.T) {
+	testCases := map[string]struct {
+		content         string
+		expectedContent string
+	}{
+		"unchanged":           {content: "print('load')", expectedContent: "print('load')"},
+		"trailing newlines":   {content: "print('load')\n\n", expectedContent: "print('load')"},
+		"trailing whitespace": {content: "def load():  \n\treturn 1\t\n", expectedContent: "def load():\n\treturn 1"},
+		"carriage returns":    {content: "a = 1\r\nb = 2\r\n", expectedContent: "a = 1\nb = 2"},
+		"leading indentation": {content: "  a = 1", expectedContent: "  a = 1"},
+		"inner empty lines":   {content: "a = 1\n\n\nb = 2", expectedContent: "a = 1\n\n\nb = 2"},
+		"whitespace only":     {content: " \n\t\n", expectedContent: ""},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if content := normalizeBlockContent(testCase.content); content != testCase.expectedContent {
+				t.Errorf("expected %q, got %q", testCase.expectedContent, content)
+			}
+		})
+	}
+}
+
+func TestAccBlockResourceUpstreamBlocksValidation(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "mageai_block" "test" {
+  name            = "notes"
+  pipeline_uuid   = "example_pipeline"
+  type            = "markdown"
+  upstream_blocks = ["load_data"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid\s+upstream\s+blocks\s+of\s+block\s+"notes":\s+markdown\s+blocks\s+are\s+not\s+run`),
+			},
+			{
+				Config: testAccProviderConfig(server) + testAccBlockResourceUpstreamBlocksValidationConfig(`[]`, `[mageai_block.loader.uuid]`, `[]`),
+			},
+			{
+				Config:      testAccProviderConfig(server) + testAccBlockResourceUpstreamBlocksValidationConfig(`[]`, `[mageai_block.loader.uuid]`, `["missing_block"]`),
+				ExpectError: regexp.MustCompile(`Upstream\s+block\s+"missing_block"\s+of\s+block\s+"export_data"\s+does\s+not\s+exist`),
+			},
+			{
+				Config:      testAccProviderConfig(server) + testAccBlockResourceUpstreamBlocksValidationConfig(`["transform_data"]`, `[mageai_block.loader.uuid]`, `[]`),
+				ExpectError: regexp.MustCompile(`load_data\s+->\s+transform_data\s+->\s+load_data`),
+			},
+			{
+				Config:      testAccProviderConfig(server) + testAccBlockResourceUpstreamBlocksValidationConfig(`["export_data"]`, `[mageai_block.loader.uuid]`, `[]`),
+				ExpectError: regexp.MustCompile(`Block\s+"export_data"\s+\(data_exporter\)\s+cannot\s+be\s+an\s+upstream\s+block\s+of\s+block\s+"load_data"\s+\(data_loader\)`),
+			},
+			{
+				Config:      testAccProviderConfig(server) + testAccBlockResourceUpstreamBlocksValidationConfig(`[]`, `["transform_data"]`, `[]`),
+				ExpectError: regexp.MustCompile(`Block\s+"transform_data"\s+cannot\s+be\s+an\s+upstream\s+block\s+of\s+itself`),
+			},
+			{
+				Config: testAccProviderConfig(server) + testAccBlockResourceUpstreamBlocksValidationConfig(`[]`, `[mageai_block.loader.uuid]`, `["transform_data"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("mageai_block.exporter", "upstream_blocks.*", "transform_data"),
+				),
+			},
+		},
+	})
+}
+
+func testAccBlockResourceUpstreamBlocksValidationConfig(loaderUpstreamBlocks, transformerUpstreamBlocks, exporterUpstreamBlocks string) string {
+	return fmt.Sprintf(`
+resource "mageai_pipeline" "test" {
+  name = "example_pipeline"
+}
+
+resource "mageai_block" "loader" {
+  name            = "load_data"
+  pipeline_uuid   = mageai_pipeline.test.uuid
+  type            = "data_loader"
+  content         = "print('load')"
+  upstream_blocks = %s
+}
+
+resource "mageai_block" "transformer" {
+  name            = "transform_data"
+  pipeline_uuid   = mageai_pipeline.test.uuid
+  type            = "transformer"
+  content         = "print('transform')"
+  upstream_blocks = %s
+}
+
+resource "mageai_block" "exporter" {
+  name            = "export_data"
+  pipeline_uuid   = mageai_pipeline.test.uuid
+  type            = "data_exporter"
+  content         = "print('export')"
+  upstream_blocks = %s
+
+  # Destroy the exporter first even when its upstream blocks are not references
+  depends_on = [mageai_block.transformer]
+}
+`, loaderUpstreamBlocks, transformerUpstreamBlocks, exporterUpstreamBlocks)
+}
+
+func TestValidateUpstreamBlocks(t *testing.T) {
+	pipelineBlocks := []mageai.Block{
+		{UUID: "load_data", Type: "data_loader"},
+		{UUID: "clean_data", Type: "transformer", UpstreamBlocks: []string{"load_data"}},
+		{UUID: "transform_data", Type: "transformer", UpstreamBlocks: []string{"clean_data"}},
+		{UUID: "export_data", Type: "data_exporter", UpstreamBlocks: []string{"transform_data"}},
+		{UUID: "notes", Type: "markdown"},
+	}
+
+	testCases := map[string]struct {
+		blockUUID      string
+		blockType      string
+		upstreamBlocks []string
+		expectedError  string
+	}{
+		"new block": {
+			blockType:      "transformer",
+			upstreamBlocks: []string{"load_data", "export_data"},
+		},
+		"new block with missing upstream block": {
+			blockType:      "transformer",
+			upstreamBlocks: []string{"missing_block"},
+			expectedError:  `Upstream block "missing_block" of the new transformer block does not exist in the pipeline.`,
+		},
+		"existing block": {
+			blockUUID:      "transform_data",
+			blockType:      "transformer",
+			upstreamBlocks: []string{"load_data"},
+		},
+		"cycle": {
+			blockUUID:      "load_data",
+			blockType:      "data_loader",
+			upstreamBlocks: []string{"transform_data"},
+			expectedError:  `Block "load_data" cannot depend on "transform_data", which already depends on it: load_data -> transform_data -> clean_data -> load_data.`,
+		},
+		"replaced upstream blocks": {
+			blockUUID:      "clean_data",
+			blockType:      "transformer",
+			upstreamBlocks: []string{},
+		},
+		"markdown upstream block": {
+			blockUUID:      "clean_data",
+			blockType:      "transformer",
+			upstreamBlocks: []string{"notes"},
+			expectedError:  `Block "notes" (markdown) cannot be an upstream block of block "clean_data" (transformer): markdown blocks are not run with the pipeline and cannot be upstream blocks.`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diags := validateUpstreamBlocks(path.Root("upstream_blocks"), testCase.blockUUID, testCase.blockType, testCase.upstreamBlocks, pipelineBlocks)
+
+			if testCase.expectedError == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+
+			if diags.ErrorsCount() != 1 || !strings.Contains(diags.Errors()[0].Detail(), testCase.expectedError) {
+				t.Errorf("expected the error %q, got %v", testCase.expectedError, diags)
+			}
+		})
+	}
+}
@@ -41,6 +41,7 @@ func (d *BlocksDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				Required:    true,
 				Description: "The UUID of the pipeline to fetch the blocks from.",
 			},
+			"project": projectDataSourceAttribute(),
 			"blocks": schema.ListNestedAttribute{
 				Computed:    true,
 				Description: "The blocks objects of a pipeline.",
@@ -196,12 +197,14 @@ func (d *BlocksDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	readDatabaseResponse, err := d.client.BlockAPI().ReadBlocks(ctx, state.PipelineUUID.ValueStringPointer())
+	client := projectClient(d.client, state.Project)
+	readDatabaseResponse, err := client.BlockAPI().ReadBlocks(ctx, state.PipelineUUID.ValueStringPointer())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
 			"Error getting block",
-			err.Error(),
-		)
+			"",
+			err,
+		))
 		return
 	}
 
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func TestAccBlocksDataSource(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccBlockResourceUpstreamBlocksConfig("[mageai_block.loader.uuid]") + `
+data "mageai_blocks" "test" {
+  pipeline_uuid = mageai_pipeline.test.uuid
+  depends_on    = [mageai_block.loader, mageai_block.transformer]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mageai_blocks.test", "blocks.#", "2"),
+					resource.TestCheckResourceAttr("data.mageai_blocks.test", "blocks.0.uuid", "load_data"),
+					resource.TestCheckTypeSetElemAttr("data.mageai_blocks.test", "blocks.0.downstream_blocks.*", "transform_data"),
+					resource.TestCheckResourceAttr("data.mageai_blocks.test", "blocks.1.uuid", "transform_data"),
+					resource.TestCheckTypeSetElemAttr("data.mageai_blocks.test", "blocks.1.upstream_blocks.*", "load_data"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,537 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// unlinkedBlockTypes are the types of blocks that are not run with the
+// pipeline, and cannot have upstream or downstream blocks.
+var unlinkedBlockTypes = []string{"markdown", "scratchpad"}
+
+// illegalUpstreamBlock returns why a block of type upstreamType cannot be an
+// upstream block of a block of type blockType, or an empty string when the
+// dependency is allowed.
+func illegalUpstreamBlock(upstreamType, blockType string) string {
+	switch {
+	case slices.Contains(unlinkedBlockTypes, upstreamType):
+		return fmt.Sprintf("%s blocks are not run with the pipeline and cannot be upstream blocks", upstreamType)
+	case slices.Contains(unlinkedBlockTypes, blockType):
+		return fmt.Sprintf("%s blocks are not run with the pipeline and cannot have upstream blocks", blockType)
+	case upstreamType == "data_exporter" && blockType == "data_loader":
+		return "a data loader cannot depend on a data exporter"
+	}
+	return ""
+}
+
+// validateUpstreamBlocks validates the upstream blocks of a block against the
+// blocks of its pipeline. It returns an error for every upstream block that
+// does not exist, that cannot be an upstream block of the block, or that
+// depends on the block. blockUUID is empty when the block does not exist
+// yet, in which case it cannot be part of a cycle.
+func validateUpstreamBlocks(attributePath path.Path, blockUUID, blockType string, upstreamBlocks []string, pipelineBlocks []mageai.Block) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	blocks := make(map[string]mageai.Block, len(pipelineBlocks))
+	for _, block := range pipelineBlocks {
+		blocks[block.UUID] = block
+	}
+
+	name := fmt.Sprintf("block %q", blockUUID)
+	if blockUUID == "" {
+		name = "the new " + blockType + " block"
+	}
+
+	for _, upstreamUUID := range upstreamBlocks {
+		if upstreamUUID == blockUUID {
+			diags.AddAttributeError(
+				attributePath,
+				"Invalid Upstream Block",
+				fmt.Sprintf("Block %q cannot be an upstream block of itself.", blockUUID),
+			)
+			continue
+		}
+
+		upstreamBlock, ok := blocks[upstreamUUID]
+		if !ok {
+			diags.AddAttributeError(
+				attributePath,
+				"Unknown Upstream Block",
+				fmt.Sprintf("Upstream block %q of %s does not exist in the pipeline. "+
+					"If the block is created with Terraform, reference the uuid attribute of its mageai_block resource so that it is created first.", upstreamUUID, name),
+			)
+			continue
+		}
+
+		if reason := illegalUpstreamBlock(upstreamBlock.Type, blockType); reason != "" {
+			diags.AddAttributeError(
+				attributePath,
+				"Invalid Upstream Block",
+				fmt.Sprintf("Block %q (%s) cannot be an upstream block of %s (%s): %s.", upstreamUUID, upstreamBlock.Type, name, blockType, reason),
+			)
+			continue
+		}
+
+		if blockUUID == "" {
+			continue
+		}
+
+		if cycle := findUpstreamPath(blocks, upstreamUUID, blockUUID, nil); cycle != nil {
+			cycle = append([]string{blockUUID}, cycle...)
+			diags.AddAttributeError(
+				attributePath,
+				"Upstream Blocks Cycle",
+				fmt.Sprintf("Block %q cannot depend on %q, which already depends on it: %s.", blockUUID, upstreamUUID, strings.Join(cycle, " -> ")),
+			)
+		}
+	}
+	return diags
+}
+
+// findUpstreamPath returns the blocks from the block with UUID from to the
+// block with UUID to, following upstream blocks, or nil when from does not
+// depend on to. The current upstream blocks of to are never followed, since
+// they are being replaced.
+func findUpstreamPath(blocks map[string]mageai.Block, from, to string, visited map[string]bool) []string {
+	if from == to {
+		return []string{to}
+	}
+
+	if visited == nil {
+		visited = map[string]bool{}
+	}
+
+	if visited[from] {
+		return nil
+	}
+	visited[from] = true
+
+	for _, upstreamUUID := range blocks[from].UpstreamBlocks {
+		if upstreamPath := findUpstreamPath(blocks, upstreamUUID, to, visited); upstreamPath != nil {
+			return append([]string{from}, upstreamPath...)
+		}
+	}
+	return nil
+}
+
+// pipelineGraph is the DAG of the blocks of a pipeline that are run with the
+// pipeline. Blocks and edges are kept in the order of the blocks in the
+// pipeline, so that the outputs are stable.
+type pipelineGraph struct {
+	blocks     []mageai.Block
+	downstream map[string][]string
+	upstream   map[string][]string
+}
+
+// newPipelineGraph returns the graph of the blocks, built from both their
+// upstream and downstream blocks. Edges to blocks that are not in the graph
+// are ignored.
+func newPipelineGraph(blocks []mageai.Block) *pipelineGraph {
+	g := &pipelineGraph{
+		downstream: map[string][]string{},
+		upstream:   map[string][]string{},
+	}
+
+	index := map[string]int{}
+	for _, block := range blocks {
+		if slices.Contains(unlinkedBlockTypes, block.Type) {
+			continue
+		}
+		index[block.UUID] = len(g.blocks)
+		g.blocks = append(g.blocks, block)
+		g.downstream[block.UUID] = []string{}
+		g.upstream[block.UUID] = []string{}
+	}
+
+	addEdge := func(from, to string) {
+		_, fromOK := index[from]
+		_, toOK := index[to]
+		if !fromOK || !toOK || slices.Contains(g.downstream[from], to) {
+			return
+		}
+		g.downstream[from] = append(g.downstream[from], to)
+		g.upstream[to] = append(g.upstream[to], from)
+	}
+
+	for _, block := range g.blocks {
+		for _, upstreamUUID := range block.UpstreamBlocks {
+			addEdge(upstreamUUID, block.UUID)
+		}
+		for _, downstreamUUID := range block.DownstreamBlocks {
+			addEdge(block.UUID, downstreamUUID)
+		}
+	}
+
+	byIndex := func(a, b string) int { return index[a] - index[b] }
+	for _, block := range g.blocks {
+		slices.SortFunc(g.downstream[block.UUID], byIndex)
+		slices.SortFunc(g.upstream[block.UUID], byIndex)
+	}
+	return g
+}
+
+// roots returns the blocks without upstream blocks.
+func (g *pipelineGraph) roots() []string {
+	roots := []string{}
+	for _, block := range g.blocks {
+		if len(g.upstream[block.UUID]) == 0 {
+			roots = append(roots, block.UUID)
+		}
+	}
+	return roots
+}
+
+// leaves returns the blocks without downstream blocks.
+func (g *pipelineGraph) leaves() []string {
+	leaves := []string{}
+	for _, block := range g.blocks {
+		if len(g.downstream[block.UUID]) == 0 {
+			leaves = append(leaves, block.UUID)
+		}
+	}
+	return leaves
+}
+
+// topologicalOrder returns the blocks ordered so that every block comes after
+// its upstream blocks, in the order of the pipeline when several blocks can
+// come next. It fails when the blocks form a cycle.
+func (g *pipelineGraph) topologicalOrder() ([]string, error) {
+	remainingUpstream := map[string]int{}
+	for _, block := range g.blocks {
+		remainingUpstream[block.UUID] = len(g.upstream[block.UUID])
+	}
+
+	order := []string{}
+	for len(order) < len(g.blocks) {
+		next := ""
+		for _, block := range g.blocks {
+			if remainingUpstream[block.UUID] == 0 {
+				next = block.UUID
+				break
+			}
+		}
+
+		if next == "" {
+			cycle := []string{}
+			for _, block := range g.blocks {
+				if remainingUpstream[block.UUID] > 0 {
+					cycle = append(cycle, block.UUID)
+				}
+			}
+			return nil, fmt.Errorf("the blocks %s form or depend on a cycle", strings.Join(cycle, ", "))
+		}
+
+		order = append(order, next)
+		remainingUpstream[next] = -1
+		for _, downstreamUUID := range g.downstream[next] {
+			remainingUpstream[downstreamUUID]--
+		}
+	}
+	return order, nil
+}
+
+// adjacencyJSON returns a JSON object with the downstream blocks of every
+// block.
+func (g *pipelineGraph) adjacencyJSON() (string, error) {
+	adjacency, err := json.Marshal(g.downstream)
+	if err != nil {
+		return "", err
+	}
+	return string(adjacency), nil
+}
+
+// dot returns the graph in the Graphviz DOT language.
+func (g *pipelineGraph) dot(name string) string {
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph \"%s\" {\n", escape(name))
+	for _, block := range g.blocks {
+		fmt.Fprintf(&b, "  \"%s\" [label=\"%s\"];\n", escape(block.UUID), escape(blockLabel(block)))
+	}
+	for _, block := range g.blocks {
+		for _, downstreamUUID := range g.downstream[block.UUID] {
+			fmt.Fprintf(&b, "  \"%s\" -> \"%s\";\n", escape(block.UUID), escape(downstreamUUID))
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// mermaid returns the graph as a Mermaid flowchart. Blocks are identified by
+// their position, since block UUIDs can contain characters that are not
+// valid in Mermaid identifiers.
+func (g *pipelineGraph) mermaid() string {
+	escape := strings.NewReplacer(`"`, "#quot;").Replace
+
+	ids := map[string]string{}
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+	for i, block := range g.blocks {
+		ids[block.UUID] = fmt.Sprintf("block%d", i)
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[block.UUID], escape(blockLabel(block)))
+	}
+	for _, block := range g.blocks {
+		for _, downstreamUUID := range g.downstream[block.UUID] {
+			fmt.Fprintf(&b, "  %s --> %s\n", ids[block.UUID], ids[downstreamUUID])
+		}
+	}
+	return b.String()
+}
+
+func blockLabel(block mageai.Block) string {
+	return fmt.Sprintf("%s (%s)", block.UUID, block.Type)
+}
+
+// dagBlock is a block declared in a mageai_pipeline_dag resource. Its upstream
+// blocks are the names of other blocks of the resource.
+type dagBlock struct {
+	blockType string
+	content   string
+	// ignoreContent is set when the content is not configured, the content of
+	// the block, e.g. the template Mage AI writes in new blocks, is then kept.
+	ignoreContent  bool
+	language       string
+	name           string
+	upstreamBlocks []string
+}
+
+type dagAction string
+
+const (
+	dagCreate dagAction = "create"
+	dagDelete dagAction = "delete"
+	dagDetach dagAction = "detach"
+	dagUpdate dagAction = "update"
+)
+
+// dagOperation is a change of a block of a pipeline, identified by its name.
+// The upstream blocks of a detach operation are the UUIDs of the upstream
+// blocks the block keeps.
+type dagOperation struct {
+	action         dagAction
+	name           string
+	upstreamBlocks []string
+}
+
+func (o dagOperation) String() string {
+	if o.action == dagDetach {
+		return fmt.Sprintf("%s %s from all but [%s]", o.action, o.name, strings.Join(o.upstreamBlocks, ", "))
+	}
+	return fmt.Sprintf("%s %s", o.action, o.name)
+}
+
+// validateDAGBlocks validates the upstream blocks of the blocks of a
+// mageai_pipeline_dag resource against each other. Unknown upstream blocks and
+// types, left empty, are not validated.
+func validateDAGBlocks(attributePath path.Path, blocks map[string]dagBlock) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	names := slices.Sorted(maps.Keys(blocks))
+	for _, name := range names {
+		block := blocks[name]
+		upstreamPath := attributePath.AtMapKey(name).AtName("upstream_blocks")
+		for _, upstreamName := range block.upstreamBlocks {
+			upstreamBlock, ok := blocks[upstreamName]
+			switch {
+			case upstreamName == name:
+				diags.AddAttributeError(
+					upstreamPath,
+					"Invalid Upstream Block",
+					fmt.Sprintf("Block %q cannot be an upstream block of itself.", name),
+				)
+			case !ok:
+				diags.AddAttributeError(
+					upstreamPath,
+					"Unknown Upstream Block",
+					fmt.Sprintf("Upstream block %q of block %q is not one of the blocks of the resource: %s.", upstreamName, name, strings.Join(names, ", ")),
+				)
+			case upstreamBlock.blockType != "" && block.blockType != "":
+				if reason := illegalUpstreamBlock(upstreamBlock.blockType, block.blockType); reason != "" {
+					diags.AddAttributeError(
+						upstreamPath,
+						"Invalid Upstream Block",
+						fmt.Sprintf("Block %q (%s) cannot be an upstream block of block %q (%s): %s.", upstreamName, upstreamBlock.blockType, name, block.blockType, reason),
+					)
+				}
+			}
+		}
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	if _, err := blockOrder(dagBlocksAsPipelineBlocks(blocks)); err != nil {
+		diags.AddAttributeError(
+			attributePath,
+			"Upstream Blocks Cycle",
+			fmt.Sprintf("The blocks cannot be ordered so that every block comes after its upstream blocks: %s.", err),
+		)
+	}
+	return diags
+}
+
+// planPipelineDAG returns the operations that change the blocks of a pipeline
+// from current to desired, ordered so that no block ever depends on a block
+// that does not exist:
+//
+//  1. Blocks that are kept are detached from the blocks that are deleted.
+//  2. Blocks that are not desired anymore, or whose type changes, are deleted,
+//     downstream blocks first.
+//  3. Desired blocks are created or updated, upstream blocks first.
+//
+// Blocks are matched by name, see dagBlockName. Blocks that do not change are
+// left alone.
+func planPipelineDAG(current []mageai.Block, desired map[string]dagBlock) ([]dagOperation, error) {
+	currentByName := make(map[string]mageai.Block, len(current))
+	obsolete := map[string]bool{}
+	for _, block := range current {
+		currentByName[dagBlockName(block)] = block
+		if desiredBlock, ok := desired[dagBlockName(block)]; !ok || desiredBlock.blockType != block.Type {
+			obsolete[block.UUID] = true
+		}
+	}
+
+	operations := []dagOperation{}
+	for _, block := range current {
+		if obsolete[block.UUID] {
+			continue
+		}
+
+		upstreamBlocks := slices.DeleteFunc(slices.Clone(block.UpstreamBlocks), func(uuid string) bool { return obsolete[uuid] })
+		if len(upstreamBlocks) < len(block.UpstreamBlocks) {
+			operations = append(operations, dagOperation{action: dagDetach, name: dagBlockName(block), upstreamBlocks: upstreamBlocks})
+		}
+	}
+
+	currentOrder, err := blockOrder(current)
+	if err != nil {
+		return nil, fmt.Errorf("could not order the blocks of the pipeline: %w", err)
+	}
+
+	for _, block := range slices.Backward(currentOrder) {
+		if obsolete[block.UUID] {
+			operations = append(operations, dagOperation{action: dagDelete, name: dagBlockName(block)})
+		}
+	}
+
+	desiredOrder, err := blockOrder(dagBlocksAsPipelineBlocks(desired))
+	if err != nil {
+		return nil, fmt.Errorf("could not order the blocks: %w", err)
+	}
+
+	for _, block := range desiredOrder {
+		currentBlock, ok := currentByName[block.Name]
+		switch {
+		case !ok || obsolete[currentBlock.UUID]:
+			operations = append(operations, dagOperation{action: dagCreate, name: block.Name})
+		case dagBlockChanged(currentBlock, desired[block.Name], currentByName, obsolete):
+			operations = append(operations, dagOperation{action: dagUpdate, name: block.Name})
+		}
+	}
+	return operations, nil
+}
+
+// unmanagedDAGBlocks returns the blocks of the pipeline that are not managed,
+// e.g. added since the last refresh, as desired blocks that do not change but
+// for their managed upstream blocks. Planned against them, the deletion of the
+// managed blocks first detaches the unmanaged blocks, since Mage AI does not
+// delete blocks that have downstream blocks.
+func unmanagedDAGBlocks(blocks []mageai.Block, managed func(mageai.Block) bool) map[string]dagBlock {
+	unmanaged := map[string]mageai.Block{}
+	for _, block := range blocks {
+		if !managed(block) {
+			unmanaged[block.UUID] = block
+		}
+	}
+
+	desired := make(map[string]dagBlock, len(unmanaged))
+	for _, block := range unmanaged {
+		desiredBlock := dagBlock{
+			blockType:     block.Type,
+			ignoreContent: true,
+			name:          dagBlockName(block),
+		}
+		for _, upstreamUUID := range block.UpstreamBlocks {
+			if upstreamBlock, ok := unmanaged[upstreamUUID]; ok {
+				desiredBlock.upstreamBlocks = append(desiredBlock.upstreamBlocks, dagBlockName(upstreamBlock))
+			}
+		}
+		desired[desiredBlock.name] = desiredBlock
+	}
+	return desired
+}
+
+// dagBlockChanged reports whether a block that is kept differs from the
+// desired block, once detached from the blocks that are deleted.
+func dagBlockChanged(block mageai.Block, desiredBlock dagBlock, currentByName map[string]mageai.Block, obsolete map[string]bool) bool {
+	if !desiredBlock.ignoreContent && normalizeBlockContent(block.Content) != normalizeBlockContent(desiredBlock.content) {
+		return true
+	}
+
+	if desiredBlock.language != "" && desiredBlock.language != block.Language {
+		return true
+	}
+
+	upstreamBlocks := []string{}
+	for _, upstreamName := range desiredBlock.upstreamBlocks {
+		upstreamBlock, ok := currentByName[upstreamName]
+		if !ok || obsolete[upstreamBlock.UUID] {
+			return true
+		}
+		upstreamBlocks = append(upstreamBlocks, upstreamBlock.UUID)
+	}
+
+	currentUpstreamBlocks := slices.DeleteFunc(slices.Clone(block.UpstreamBlocks), func(uuid string) bool { return obsolete[uuid] })
+	slices.Sort(upstreamBlocks)
+	slices.Sort(currentUpstreamBlocks)
+	return !slices.Equal(upstreamBlocks, currentUpstreamBlocks)
+}
+
+// blockOrder returns the blocks ordered so that every block comes after its
+// upstream blocks, followed by the blocks that are not run with the pipeline.
+func blockOrder(blocks []mageai.Block) ([]mageai.Block, error) {
+	order, err := newPipelineGraph(blocks).topologicalOrder()
+	if err != nil {
+		return nil, err
+	}
+
+	byUUID := make(map[string]mageai.Block, len(blocks))
+	for _, block := range blocks {
+		byUUID[block.UUID] = block
+	}
+
+	ordered := make([]mageai.Block, 0, len(blocks))
+	for _, uuid := range order {
+		ordered = append(ordered, byUUID[uuid])
+	}
+	for _, block := range blocks {
+		if slices.Contains(unlinkedBlockTypes, block.Type) {
+			ordered = append(ordered, block)
+		}
+	}
+	return ordered, nil
+}
+
+// dagBlocksAsPipelineBlocks returns the blocks as pipeline blocks identified
+// by their names, sorted by name.
+func dagBlocksAsPipelineBlocks(blocks map[string]dagBlock) []mageai.Block {
+	pipelineBlocks := make([]mageai.Block, 0, len(blocks))
+	for _, name := range slices.Sorted(maps.Keys(blocks)) {
+		pipelineBlocks = append(pipelineBlocks, mageai.Block{
+			Name:           name,
+			Type:           blocks[name].blockType,
+			UpstreamBlocks: blocks[name].upstreamBlocks,
+			UUID:           name,
+		})
+	}
+	return pipelineBlocks
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// newClientErrorDiagnostic returns an error diagnostic for an error returned by
+// the Mage AI client. Canceled, timed out and unauthorized requests get a
+// dedicated detail, any other error is appended to the given detail.
+func newClientErrorDiagnostic(summary string, detail string, err error) diag.Diagnostic {
+	switch {
+	case mageai.IsCanceled(err):
+		return diag.NewErrorDiagnostic(
+			summary,
+			"The request to Mage AI was canceled before it completed. "+
+				"The operation may have been partially applied, refresh the state before retrying.\n\n"+
+				"Mage AI Client Error: "+err.Error(),
+		)
+	case mageai.IsTimeout(err):
+		return diag.NewErrorDiagnostic(
+			summary,
+			"The request to Mage AI did not complete before the deadline. "+
+				"Check that the Mage AI server is reachable, or increase the operation timeout and retry.\n\n"+
+				"Mage AI Client Error: "+err.Error(),
+		)
+	case mageai.IsUnauthorized(err):
+		return diag.NewErrorDiagnostic(
+			summary,
+			"Mage AI rejected the credentials of the request. "+
+				"Check the api_key provider attribute or the MAGEAI_API_KEY environment variable, "+
+				"and, when user authentication is enabled, the username and password provider attributes "+
+				"or the MAGEAI_USERNAME and MAGEAI_PASSWORD environment variables.\n\n"+
+				"Mage AI Client Error: "+err.Error(),
+		)
+	}
+	return diag.NewErrorDiagnostic(summary, detail+err.Error())
+}
@@ -0,0 +1,313 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+	"gopkg.in/yaml.v3"
+)
+
+type IOConfigProfileResourceModel struct {
+	BigQuery  types.Object `tfsdk:"bigquery"`
+	ExtraYAML types.String `tfsdk:"extra_yaml"`
+	FilePath  types.String `tfsdk:"file_path"`
+	Name      types.String `tfsdk:"name"`
+	Postgres  types.Object `tfsdk:"postgres"`
+	Project   types.String `tfsdk:"project"`
+	Snowflake types.Object `tfsdk:"snowflake"`
+}
+
+// connectorValues returns the attributes of the model holding the connectors,
+// keyed by the name of the attribute.
+func (m *IOConfigProfileResourceModel) connectorValues() map[string]*types.Object {
+	return map[string]*types.Object{
+		"bigquery":  &m.BigQuery,
+		"postgres":  &m.Postgres,
+		"snowflake": &m.Snowflake,
+	}
+}
+
+// ioConfigFieldKind is how the value of a connector attribute is written in
+// io_config.yaml.
+type ioConfigFieldKind int
+
+const (
+	// ioConfigString values are written as strings.
+	ioConfigString ioConfigFieldKind = iota
+	// ioConfigInteger values are written as integers, unless they are
+	// templates such as {{ env_var('POSTGRES_PORT') }}.
+	ioConfigInteger
+	// ioConfigJSON values are JSON objects written as YAML mappings.
+	ioConfigJSON
+)
+
+// ioConfigField is an attribute of a connector, stored under a key of the
+// profile.
+type ioConfigField struct {
+	attribute   string
+	key         string
+	description string
+	kind        ioConfigFieldKind
+	sensitive   bool
+}
+
+// ioConfigConnector is a typed attribute of the profile, grouping the keys Mage
+// AI reads to connect to a database or a data warehouse.
+type ioConfigConnector struct {
+	attribute   string
+	description string
+	fields      []ioConfigField
+}
+
+var ioConfigConnectors = []ioConfigConnector{
+	{
+		attribute:   "bigquery",
+		description: "The connection to BigQuery, authenticated with a service account.",
+		fields: []ioConfigField{
+			{attribute: "location", key: "GOOGLE_LOCATION", description: "The location of the datasets, e.g. `US`."},
+			{attribute: "service_account_key", key: "GOOGLE_SERVICE_ACC_KEY", description: "The JSON key of the service account. Conflicts with `service_account_key_file`.", kind: ioConfigJSON, sensitive: true},
+			{attribute: "service_account_key_file", key: "GOOGLE_SERVICE_ACC_KEY_FILEPATH", description: "The path of the JSON key of the service account on the Mage AI server. Conflicts with `service_account_key`."},
+		},
+	},
+	{
+		attribute:   "postgres",
+		description: "The connection to a PostgreSQL database.",
+		fields: []ioConfigField{
+			{attribute: "connect_timeout", key: "POSTGRES_CONNECT_TIMEOUT", description: "The timeout (in seconds) of the connection.", kind: ioConfigInteger},
+			{attribute: "database", key: "POSTGRES_DBNAME", description: "The name of the database."},
+			{attribute: "host", key: "POSTGRES_HOST", description: "The host of the database server."},
+			{attribute: "password", key: "POSTGRES_PASSWORD", description: "The password of the user.", sensitive: true},
+			{attribute: "port", key: "POSTGRES_PORT", description: "The port of the database server, e.g. `5432`.", kind: ioConfigInteger},
+			{attribute: "schema", key: "POSTGRES_SCHEMA", description: "The default schema."},
+			{attribute: "user", key: "POSTGRES_USER", description: "The user to connect as."},
+		},
+	},
+	{
+		attribute:   "snowflake",
+		description: "The connection to a Snowflake account, authenticated with a password or a private key.",
+		fields: []ioConfigField{
+			{attribute: "account", key: "SNOWFLAKE_ACCOUNT", description: "The identifier of the account, e.g. `myorg-account`."},
+			{attribute: "database", key: "SNOWFLAKE_DEFAULT_DB", description: "The default database."},
+			{attribute: "password", key: "SNOWFLAKE_PASSWORD", description: "The password of the user.", sensitive: true},
+			{attribute: "private_key_passphrase", key: "SNOWFLAKE_PRIVATE_KEY_PASSPHRASE", description: "The passphrase of the private key.", sensitive: true},
+			{attribute: "private_key_path", key: "SNOWFLAKE_PRIVATE_KEY_PATH", description: "The path of the private key of the user on the Mage AI server."},
+			{attribute: "role", key: "SNOWFLAKE_ROLE", description: "The role to use."},
+			{attribute: "schema", key: "SNOWFLAKE_DEFAULT_SCHEMA", description: "The default schema."},
+			{attribute: "user", key: "SNOWFLAKE_USER", description: "The user to connect as."},
+			{attribute: "warehouse", key: "SNOWFLAKE_DEFAULT_WH", description: "The default warehouse."},
+		},
+	},
+}
+
+// ioConfigConnectorOfKey returns the connector storing the key, and whether
+// the key belongs to a connector.
+func ioConfigConnectorOfKey(key string) (ioConfigConnector, bool) {
+	for _, connector := range ioConfigConnectors {
+		for _, field := range connector.fields {
+			if field.key == key {
+				return connector, true
+			}
+		}
+	}
+	return ioConfigConnector{}, false
+}
+
+func (c ioConfigConnector) attrTypes() map[string]attr.Type {
+	attrTypes := map[string]attr.Type{}
+	for _, field := range c.fields {
+		attrTypes[field.attribute] = types.StringType
+	}
+	return attrTypes
+}
+
+// makeIOConfigProfileFromModel returns the profile to write in io_config.yaml.
+func makeIOConfigProfileFromModel(model IOConfigProfileResourceModel) (mageai.IOConfigProfile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	profile := mageai.IOConfigProfile{}
+
+	if !model.ExtraYAML.IsNull() {
+		extra, err := parseIOConfigExtraYAML(model.ExtraYAML.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("extra_yaml"), "Invalid Extra YAML", err.Error())
+			return nil, diags
+		}
+		maps.Copy(profile, extra)
+	}
+
+	connectorValues := model.connectorValues()
+	for _, connector := range ioConfigConnectors {
+		value := connectorValues[connector.attribute]
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		attributes := value.Attributes()
+		for _, field := range connector.fields {
+			fieldValue, ok := attributes[field.attribute].(types.String)
+			if !ok || fieldValue.IsNull() || fieldValue.IsUnknown() {
+				continue
+			}
+
+			written, err := field.write(fieldValue.ValueString())
+			if err != nil {
+				diags.AddAttributeError(path.Root(connector.attribute).AtName(field.attribute), "Invalid Connection Value", err.Error())
+				continue
+			}
+			profile[field.key] = written
+		}
+	}
+	return profile, diags
+}
+
+// write returns the value of the field as written in io_config.yaml.
+func (f ioConfigField) write(value string) (any, error) {
+	switch f.kind {
+	case ioConfigInteger:
+		if n, err := strconv.Atoi(value); err == nil {
+			return n, nil
+		}
+	case ioConfigJSON:
+		var object map[string]any
+		if err := json.Unmarshal([]byte(value), &object); err != nil {
+			return nil, fmt.Errorf("%s must be a JSON object: %w", f.attribute, err)
+		}
+		return object, nil
+	}
+	return value, nil
+}
+
+// read returns the value of the field read from io_config.yaml. The prior
+// value is kept when it is equivalent, so that formatting a JSON value
+// differently is not a change.
+func (f ioConfigField) read(value any, prior types.String) (types.String, error) {
+	switch value := value.(type) {
+	case nil:
+		return types.StringNull(), nil
+	case string:
+		return types.StringValue(value), nil
+	case bool:
+		return types.StringValue(strconv.FormatBool(value)), nil
+	case int:
+		return types.StringValue(strconv.Itoa(value)), nil
+	case float64:
+		return types.StringValue(strconv.FormatFloat(value, 'f', -1, 64)), nil
+	case map[string]any:
+		if f.kind != ioConfigJSON {
+			break
+		}
+
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return types.StringNull(), fmt.Errorf("error encoding %s: %w", f.key, err)
+		}
+
+		if !prior.IsNull() && !prior.IsUnknown() && equalJSON(encoded, []byte(prior.ValueString())) {
+			return prior, nil
+		}
+		return types.StringValue(string(encoded)), nil
+	}
+	// The value is not part of the error since it can be a credential
+	return types.StringNull(), fmt.Errorf("unexpected value of %s, expected a string or a number", f.key)
+}
+
+// equalJSON reports whether the JSON values are equivalent.
+func equalJSON(a, b []byte) bool {
+	var valueA, valueB any
+	if json.Unmarshal(a, &valueA) != nil || json.Unmarshal(b, &valueB) != nil {
+		return false
+	}
+	return reflect.DeepEqual(valueA, valueB)
+}
+
+// parseIOConfigExtraYAML parses the extra keys of the profile, which must be a
+// YAML mapping without the keys of the connectors.
+func parseIOConfigExtraYAML(extraYAML string) (map[string]any, error) {
+	extra := map[string]any{}
+	if err := yaml.Unmarshal([]byte(extraYAML), &extra); err != nil {
+		return nil, fmt.Errorf("extra_yaml must be a YAML mapping: %w", err)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(extra)) {
+		if connector, ok := ioConfigConnectorOfKey(key); ok {
+			return nil, fmt.Errorf("%s is set with the %s attribute and cannot be in extra_yaml", key, connector.attribute)
+		}
+	}
+	return extra, nil
+}
+
+// updateIOConfigProfileModel sets the connectors and the extra keys of the
+// model from the profile. A connector is set when the profile has any of its
+// keys, and the other keys are the extra keys.
+func updateIOConfigProfileModel(model *IOConfigProfileResourceModel, profile mageai.IOConfigProfile) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	extra := map[string]any(maps.Clone(profile))
+	connectorValues := model.connectorValues()
+	for _, connector := range ioConfigConnectors {
+		value := connectorValues[connector.attribute]
+		priorAttributes := value.Attributes()
+
+		found := false
+		attributes := map[string]attr.Value{}
+		for _, field := range connector.fields {
+			fieldValue, ok := profile[field.key]
+			delete(extra, field.key)
+			if !ok {
+				attributes[field.attribute] = types.StringNull()
+				continue
+			}
+			found = true
+
+			prior, _ := priorAttributes[field.attribute].(types.String)
+			attribute, err := field.read(fieldValue, prior)
+			if err != nil {
+				diags.AddAttributeError(path.Root(connector.attribute).AtName(field.attribute), "Invalid Connection Value", err.Error())
+			}
+			attributes[field.attribute] = attribute
+		}
+
+		if !found {
+			*value = types.ObjectNull(connector.attrTypes())
+			continue
+		}
+
+		object, objectDiags := types.ObjectValue(connector.attrTypes(), attributes)
+		diags.Append(objectDiags...)
+		*value = object
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	// The prior extra keys are kept when they are equivalent, so that
+	// formatting them differently is not a change
+	if !model.ExtraYAML.IsNull() && !model.ExtraYAML.IsUnknown() {
+		prior := map[string]any{}
+		if yaml.Unmarshal([]byte(model.ExtraYAML.ValueString()), &prior) == nil && reflect.DeepEqual(prior, extra) {
+			return diags
+		}
+	}
+
+	if len(extra) == 0 {
+		model.ExtraYAML = types.StringNull()
+		return diags
+	}
+
+	extraYAML, err := yaml.Marshal(extra)
+	if err != nil {
+		diags.AddAttributeError(path.Root("extra_yaml"), "Invalid Extra YAML", err.Error())
+		return diags
+	}
+	model.ExtraYAML = types.StringValue(string(extraYAML))
+	return diags
+}
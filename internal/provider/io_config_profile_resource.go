@@ -0,0 +1,352 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &IOConfigProfileResource{}
+	_ resource.ResourceWithConfigure      = &IOConfigProfileResource{}
+	_ resource.ResourceWithImportState    = &IOConfigProfileResource{}
+	_ resource.ResourceWithValidateConfig = &IOConfigProfileResource{}
+)
+
+// NewIOConfigProfileResource is a helper function to simplify the provider implementation.
+func NewIOConfigProfileResource() resource.Resource {
+	return &IOConfigProfileResource{}
+}
+
+// IOConfigProfileResource defines the resource implementation.
+type IOConfigProfileResource struct {
+	client mageai.Client
+}
+
+// Metadata returns the resource type name.
+func (r *IOConfigProfileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_io_config_profile"
+}
+
+// Schema defines the schema for the resource.
+func (r *IOConfigProfileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"extra_yaml": schema.StringAttribute{
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Additional keys of the profile as a YAML mapping, e.g. for connectors without a typed attribute. It cannot hold the keys of the typed attributes, such as `POSTGRES_HOST`.",
+		},
+		"file_path": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Default:     stringdefault.StaticString(mageai.IOConfigFilePath),
+			Description: "The path of the file holding the profile, relative to the project. Defaults to `io_config.yaml`, and changing it replaces the profile.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"name": schema.StringAttribute{
+			Required:    true,
+			Description: "The name of the profile, which blocks refer to with `configuration.data_provider_profile`. Changing it replaces the profile.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+				stringvalidator.NoneOf(mageai.IOConfigVersionKey),
+			},
+		},
+		"project": projectResourceAttribute(),
+	}
+
+	for _, connector := range ioConfigConnectors {
+		fieldAttributes := map[string]schema.Attribute{}
+		for _, field := range connector.fields {
+			fieldAttributes[field.attribute] = schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   field.sensitive,
+				Description: fmt.Sprintf("%s Stored as `%s`.", field.description, field.key),
+			}
+		}
+
+		attributes[connector.attribute] = schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: connector.description + " The values can be templates, such as `{{ env_var('PASSWORD') }}`, which Mage AI renders when it connects.",
+			Attributes:  fieldAttributes,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		Description: "Manage a connection profile of the `io_config.yaml` file of a project, which SQL blocks select with `configuration.data_provider_profile`. Only the profile is changed, the other profiles of the file and their comments are kept.",
+		Attributes:  attributes,
+	}
+}
+
+// ValidateConfig validates the values that can be checked without the file,
+// so that they fail at plan time.
+func (r *IOConfigProfileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config IOConfigProfileResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.ExtraYAML.IsNull() && !config.ExtraYAML.IsUnknown() {
+		if _, err := parseIOConfigExtraYAML(config.ExtraYAML.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("extra_yaml"), "Invalid Extra YAML", err.Error())
+		}
+	}
+
+	connectorValues := config.connectorValues()
+	for _, connector := range ioConfigConnectors {
+		value := connectorValues[connector.attribute]
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		// A connector without values would not be written, and would be
+		// planned again on every plan
+		set := 0
+		for _, field := range connector.fields {
+			fieldValue, ok := value.Attributes()[field.attribute].(types.String)
+			if !ok || fieldValue.IsNull() {
+				continue
+			}
+			set++
+
+			if field.kind != ioConfigJSON || fieldValue.IsUnknown() {
+				continue
+			}
+
+			if _, err := field.write(fieldValue.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root(connector.attribute).AtName(field.attribute), "Invalid Connection Value", err.Error())
+			}
+		}
+
+		if set == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(connector.attribute),
+				"Missing Connection Value",
+				fmt.Sprintf("At least one attribute of %s must be set.", connector.attribute),
+			)
+		}
+	}
+
+	if !config.BigQuery.IsNull() && !config.BigQuery.IsUnknown() {
+		key, _ := config.BigQuery.Attributes()["service_account_key"].(types.String)
+		keyFile, _ := config.BigQuery.Attributes()["service_account_key_file"].(types.String)
+		if !key.IsNull() && !keyFile.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("bigquery").AtName("service_account_key"),
+				"Conflicting Connection Values",
+				"Only one of service_account_key and service_account_key_file can be set.",
+			)
+		}
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *IOConfigProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan IOConfigProfileResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	profile, diags := makeIOConfigProfileFromModel(plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := projectClient(r.client, plan.Project)
+	plan.Project = projectValue(client)
+	err := client.IOConfigAPI().CreateIOConfigProfile(ctx, plan.FilePath.ValueStringPointer(), plan.Name.ValueStringPointer(), profile)
+	if err != nil {
+		detail := "Could not create io config profile, unexpected error: "
+		if mageai.IsConflict(err) {
+			detail = "The profile already exists, import it to manage it with Terraform: "
+		}
+
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error creating io config profile",
+			detail,
+			err,
+		))
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *IOConfigProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state IOConfigProfileResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed profile from Mage AI
+	client := projectClient(r.client, state.Project)
+	profile, err := client.IOConfigAPI().ReadIOConfigProfile(ctx, state.FilePath.ValueStringPointer(), state.Name.ValueStringPointer())
+	if err != nil {
+		// Remove the resource from state so that Terraform plans to recreate it
+		if mageai.IsNotFound(err) {
+			tflog.Warn(ctx, "The io config profile no longer exists, removing it from state", map[string]any{"file_path": state.FilePath.ValueString(), "name": state.Name.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error getting io config profile",
+			"",
+			err,
+		))
+		return
+	}
+
+	// Overwrite items with refreshed state
+	resp.Diagnostics.Append(updateIOConfigProfileModel(&state, profile)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *IOConfigProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan IOConfigProfileResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	profile, diags := makeIOConfigProfileFromModel(plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update existing profile
+	client := projectClient(r.client, plan.Project)
+	err := client.IOConfigAPI().UpdateIOConfigProfile(ctx, plan.FilePath.ValueStringPointer(), plan.Name.ValueStringPointer(), profile)
+	if err != nil {
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error updating io config profile",
+			"Could not update io config profile, unexpected error: ",
+			err,
+		))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *IOConfigProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state IOConfigProfileResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete existing profile
+	client := projectClient(r.client, state.Project)
+	err := client.IOConfigAPI().DeleteIOConfigProfile(ctx, state.FilePath.ValueStringPointer(), state.Name.ValueStringPointer())
+	if err != nil {
+		// The profile is already gone, there is nothing left to delete
+		if mageai.IsNotFound(err) {
+			return
+		}
+
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error deleting io config profile",
+			"Could not delete io config profile, unexpected error: ",
+			err,
+		))
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *IOConfigProfileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected mageai.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = pd.client
+}
+
+// ImportState imports a profile using an identifier of the form name, for a
+// profile of io_config.yaml, or file_path/name. Only the last slash is a
+// separator since file paths can contain slashes.
+func (r *IOConfigProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	filePath, name := mageai.IOConfigFilePath, req.ID
+	if i := strings.LastIndex(req.ID, "/"); i >= 0 {
+		filePath, name = req.ID[:i], req.ID[i+1:]
+	}
+
+	if filePath == "" || name == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: name or file_path/name. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("file_path"), filePath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	importProject(ctx, r.client, resp)
+}
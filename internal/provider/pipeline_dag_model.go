@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+type PipelineDAGResourceModel struct {
+	Blocks       map[string]PipelineDAGBlockModel `tfsdk:"blocks"`
+	PipelineUUID types.String                     `tfsdk:"pipeline_uuid"`
+	Project      types.String                     `tfsdk:"project"`
+}
+
+type PipelineDAGBlockModel struct {
+	Content          types.String `tfsdk:"content"`
+	DownstreamBlocks types.Set    `tfsdk:"downstream_blocks"`
+	Language         types.String `tfsdk:"language"`
+	Status           types.String `tfsdk:"status"`
+	Type             types.String `tfsdk:"type"`
+	UpstreamBlocks   types.Set    `tfsdk:"upstream_blocks"`
+	UUID             types.String `tfsdk:"uuid"`
+}
+
+// getDAGBlock returns the block declared by the model. Unknown values are
+// left empty.
+func getDAGBlock(name string, b PipelineDAGBlockModel) dagBlock {
+	block := dagBlock{
+		blockType:     b.Type.ValueString(),
+		content:       b.Content.ValueString(),
+		ignoreContent: b.Content.IsNull(),
+		language:      b.Language.ValueString(),
+		name:          name,
+	}
+	if !b.UpstreamBlocks.IsUnknown() {
+		for _, upstreamBlock := range convertUpstreamBlocksSetToStringSlice(b.UpstreamBlocks) {
+			if upstreamBlock != "" {
+				block.upstreamBlocks = append(block.upstreamBlocks, upstreamBlock)
+			}
+		}
+	}
+	return block
+}
+
+// getPipelineDAGBlocksModel returns the blocks of a pipeline keyed by name,
+// with their upstream and downstream blocks referenced by name. The content
+// and upstream blocks of the prior blocks are kept when they are equivalent,
+// so that the whitespace changes of Mage AI and empty sets do not show as
+// changes, and the content of the prior blocks without content is not set.
+func getPipelineDAGBlocksModel(ctx context.Context, blocks []mageai.Block, priorBlocks map[string]PipelineDAGBlockModel) (map[string]PipelineDAGBlockModel, error) {
+	names := make(map[string]string, len(blocks))
+	for _, block := range blocks {
+		names[block.UUID] = dagBlockName(block)
+	}
+
+	namesOf := func(uuids []string) []string {
+		blockNames := []string{}
+		for _, uuid := range uuids {
+			if name, ok := names[uuid]; ok {
+				uuid = name
+			}
+			blockNames = append(blockNames, uuid)
+		}
+		return blockNames
+	}
+
+	blocksModel := make(map[string]PipelineDAGBlockModel, len(blocks))
+	for _, block := range blocks {
+		name := dagBlockName(block)
+		prior, hasPrior := priorBlocks[name]
+
+		downstreamBlocks, diags := types.SetValueFrom(ctx, types.StringType, namesOf(block.DownstreamBlocks))
+		if diags.HasError() {
+			return nil, fmt.Errorf("could not get downstream blocks of block %s, unexpected error: %v", name, diags.Errors())
+		}
+
+		upstreamBlocks := types.SetNull(types.StringType)
+		if len(block.UpstreamBlocks) > 0 || hasPrior && !prior.UpstreamBlocks.IsNull() {
+			upstreamBlocks, diags = types.SetValueFrom(ctx, types.StringType, namesOf(block.UpstreamBlocks))
+			if diags.HasError() {
+				return nil, fmt.Errorf("could not get upstream blocks of block %s, unexpected error: %v", name, diags.Errors())
+			}
+		}
+
+		content := types.StringValue(block.Content)
+		switch {
+		case hasPrior && !prior.Content.IsNull() && !prior.Content.IsUnknown() && normalizeBlockContent(prior.Content.ValueString()) == normalizeBlockContent(block.Content):
+			content = prior.Content
+		case hasPrior && prior.Content.IsNull(), block.Content == "":
+			content = types.StringNull()
+		}
+
+		blocksModel[name] = PipelineDAGBlockModel{
+			Content:          content,
+			DownstreamBlocks: downstreamBlocks,
+			Language:         types.StringValue(block.Language),
+			Status:           types.StringValue(block.Status),
+			Type:             types.StringValue(block.Type),
+			UpstreamBlocks:   upstreamBlocks,
+			UUID:             types.StringValue(block.UUID),
+		}
+	}
+	return blocksModel, nil
+}
+
+// dagBlockName returns the name of a block, which is its key in a
+// mageai_pipeline_dag resource.
+func dagBlockName(block mageai.Block) string {
+	if block.Name == "" {
+		return block.UUID
+	}
+	return block.Name
+}
+
+// makeBlockRequestFromBlock returns a request that keeps all the attributes of
+// a block, to change some of them.
+func makeBlockRequestFromBlock(block mageai.Block) mageai.BlockRequest {
+	return mageai.BlockRequest{
+		Color:          block.Color,
+		Configuration:  block.Configuration,
+		Content:        block.Content,
+		ExtensionUUID:  block.ExtensionUUID,
+		Language:       block.Language,
+		Name:           block.Name,
+		Priority:       block.Priority,
+		RetryConfig:    block.RetryConfig,
+		Type:           mageai.BlockType(block.Type),
+		UpstreamBlocks: block.UpstreamBlocks,
+	}
+}
@@ -0,0 +1,422 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &PipelineDAGResource{}
+	_ resource.ResourceWithConfigure      = &PipelineDAGResource{}
+	_ resource.ResourceWithImportState    = &PipelineDAGResource{}
+	_ resource.ResourceWithValidateConfig = &PipelineDAGResource{}
+)
+
+// NewPipelineDAGResource is a helper function to simplify the provider implementation.
+func NewPipelineDAGResource() resource.Resource {
+	return &PipelineDAGResource{}
+}
+
+// PipelineDAGResource defines the resource implementation.
+type PipelineDAGResource struct {
+	client mageai.Client
+}
+
+// Metadata returns the resource type name.
+func (r *PipelineDAGResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_dag"
+}
+
+// Schema defines the schema for the resource.
+func (r *PipelineDAGResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		Description: "Manage all the blocks of a pipeline and their dependencies. Blocks are created, updated and deleted in an order that never leaves a block depending on a block that does not exist: " +
+			"blocks are detached from the blocks that are deleted, deleted downstream blocks first, then created and updated upstream blocks first. " +
+			"Blocks of the pipeline that are not declared in `blocks` are deleted, so the blocks of the pipeline must not also be managed with `mageai_block` resources. " +
+			"On destroy, the blocks added to the pipeline since the last refresh are kept, and detached from the deleted blocks.",
+		Attributes: map[string]schema.Attribute{
+			"blocks": schema.MapNestedAttribute{
+				Required:    true,
+				Description: "The blocks of the pipeline, keyed by their human readable name. Renaming a block deletes it and creates a new block.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"content": schema.StringAttribute{
+							Optional:    true,
+							Description: "Block file contents. When it is not set, the content of the block, e.g. the template Mage AI writes in new blocks, is left unmanaged.",
+						},
+						"downstream_blocks": schema.SetAttribute{
+							Computed:    true,
+							Description: "The names of the blocks that depend on this block.",
+							ElementType: types.StringType,
+						},
+						"language": schema.StringAttribute{
+							Computed:    true,
+							Optional:    true,
+							Description: "The language of the block: `python`, `sql`, `r`, `yaml`. Defaults to `python`.",
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "Status of block: `executed`, `failed`, `not_executed`, `updated`.",
+						},
+						"type": schema.StringAttribute{
+							Required:    true,
+							Description: "Type of block: `callback`, `chart`, `conditional`, `custom`, `data_exporter`, `data_loader`, `dbt`, `extension`, `global_data_product`, `markdown`, `scratchpad`, `sensor`, `transformer`. Changing the type deletes the block and creates a new block.",
+							Validators: []validator.String{
+								stringvalidator.OneOf([]string{"callback", "chart", "conditional", "custom", "data_exporter", "data_loader", "dbt", "extension", "global_data_product", "markdown", "scratchpad", "sensor", "transformer"}...),
+							},
+						},
+						"upstream_blocks": schema.SetAttribute{
+							Optional:    true,
+							Description: "The names of the blocks of `blocks` that this block depends on. The plan fails when an upstream block is not declared, when the blocks form a cycle, or when a block cannot be an upstream block of this block, e.g. a data exporter of a data loader.",
+							ElementType: types.StringType,
+						},
+						"uuid": schema.StringAttribute{
+							Computed:    true,
+							Description: "Unique identifier for the block.",
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+					},
+				},
+			},
+			"pipeline_uuid": schema.StringAttribute{
+				Required:    true,
+				Description: "The UUID of the pipeline whose blocks are managed.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": projectResourceAttribute(),
+		},
+	}
+}
+
+// ValidateConfig validates the upstream blocks of the blocks against each
+// other, so that missing blocks, cycles and illegal dependencies fail before
+// any block is changed.
+func (r *PipelineDAGResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var blocksConfig types.Map
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("blocks"), &blocksConfig)...)
+	if resp.Diagnostics.HasError() || blocksConfig.IsNull() || blocksConfig.IsUnknown() {
+		return
+	}
+
+	blocks := map[string]dagBlock{}
+	for name, blockConfig := range blocksConfig.Elements() {
+		blockObject, ok := blockConfig.(types.Object)
+		if !ok || blockObject.IsNull() || blockObject.IsUnknown() {
+			continue
+		}
+
+		var block PipelineDAGBlockModel
+		resp.Diagnostics.Append(blockObject.As(ctx, &block, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		blocks[name] = getDAGBlock(name, block)
+	}
+
+	resp.Diagnostics.Append(validateDAGBlocks(path.Root("blocks"), blocks)...)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *PipelineDAGResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan PipelineDAGResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Project = projectValue(projectClient(r.client, plan.Project))
+	refreshed, diags := r.apply(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if !refreshed {
+		return
+	}
+
+	// Save data into Terraform state, even when some blocks could not be
+	// changed, so that the blocks that were changed are tracked
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *PipelineDAGResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state PipelineDAGResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed blocks value from Mage AI
+	client := projectClient(r.client, state.Project)
+	readBlocksResponse, err := client.BlockAPI().ReadBlocks(ctx, state.PipelineUUID.ValueStringPointer())
+	if err != nil {
+		// Remove the resource from state so that Terraform plans to recreate it
+		if mageai.IsNotFound(err) {
+			tflog.Warn(ctx, "The pipeline no longer exists, removing its blocks from state", map[string]any{"pipeline_uuid": state.PipelineUUID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error getting blocks",
+			"",
+			err,
+		))
+		return
+	}
+
+	// Overwrite items with refreshed state
+	blocks, err := getPipelineDAGBlocksModel(ctx, readBlocksResponse.Blocks, state.Blocks)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting blocks",
+			err.Error(),
+		)
+		return
+	}
+	state.Blocks = blocks
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *PipelineDAGResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan PipelineDAGResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	refreshed, diags := r.apply(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if !refreshed {
+		return
+	}
+
+	// Save updated data into Terraform state, even when some blocks could not
+	// be changed, so that the blocks that were changed are tracked
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *PipelineDAGResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state PipelineDAGResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := projectClient(r.client, state.Project)
+	readBlocksResponse, err := client.BlockAPI().ReadBlocks(ctx, state.PipelineUUID.ValueStringPointer())
+	if err != nil {
+		// The pipeline is already gone, there is nothing left to delete
+		if mageai.IsNotFound(err) {
+			return
+		}
+
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error deleting blocks",
+			"Could not get the blocks of the pipeline, unexpected error: ",
+			err,
+		))
+		return
+	}
+
+	// Only the blocks of the state are deleted, downstream blocks first. The
+	// other blocks are kept, and detached from the deleted blocks
+	managed := func(block mageai.Block) bool {
+		_, ok := state.Blocks[dagBlockName(block)]
+		return ok
+	}
+	unmanaged := unmanagedDAGBlocks(readBlocksResponse.Blocks, managed)
+
+	resp.Diagnostics.Append(r.applyOperations(ctx, client, state.PipelineUUID.ValueString(), readBlocksResponse.Blocks, unmanaged)...)
+}
+
+// apply changes the blocks of the pipeline to the blocks of the plan, and
+// sets the plan to the blocks of the pipeline once changed. It returns whether
+// the plan was set, which is not the case when the blocks of the pipeline
+// could not be read.
+func (r *PipelineDAGResource) apply(ctx context.Context, plan *PipelineDAGResourceModel) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	client := projectClient(r.client, plan.Project)
+	readBlocksResponse, err := client.BlockAPI().ReadBlocks(ctx, plan.PipelineUUID.ValueStringPointer())
+	if err != nil {
+		diags.Append(newClientErrorDiagnostic(
+			"Error getting blocks",
+			"Could not get the blocks of the pipeline, unexpected error: ",
+			err,
+		))
+		return false, diags
+	}
+
+	desired := make(map[string]dagBlock, len(plan.Blocks))
+	for name, block := range plan.Blocks {
+		desired[name] = getDAGBlock(name, block)
+	}
+
+	diags.Append(r.applyOperations(ctx, client, plan.PipelineUUID.ValueString(), readBlocksResponse.Blocks, desired)...)
+
+	// Refresh the blocks, including those changed before an error
+	readBlocksResponse, err = client.BlockAPI().ReadBlocks(ctx, plan.PipelineUUID.ValueStringPointer())
+	if err != nil {
+		diags.Append(newClientErrorDiagnostic(
+			"Error getting blocks",
+			"Could not get the blocks of the pipeline, unexpected error: ",
+			err,
+		))
+		return false, diags
+	}
+
+	blocks, err := getPipelineDAGBlocksModel(ctx, readBlocksResponse.Blocks, plan.Blocks)
+	if err != nil {
+		diags.AddError(
+			"Error getting blocks",
+			err.Error(),
+		)
+		return false, diags
+	}
+	plan.Blocks = blocks
+	return true, diags
+}
+
+// applyOperations changes the blocks of a pipeline from current to desired
+// with the client, and stops at the first error.
+func (r *PipelineDAGResource) applyOperations(ctx context.Context, client mageai.Client, pipelineUUID string, current []mageai.Block, desired map[string]dagBlock) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	operations, err := planPipelineDAG(current, desired)
+	if err != nil {
+		diags.AddError(
+			"Error ordering blocks",
+			err.Error(),
+		)
+		return diags
+	}
+
+	currentByName := make(map[string]mageai.Block, len(current))
+	uuids := make(map[string]string, len(current))
+	for _, block := range current {
+		currentByName[dagBlockName(block)] = block
+		uuids[dagBlockName(block)] = block.UUID
+	}
+
+	upstreamUUIDs := func(names []string) []string {
+		upstreamBlocks := []string{}
+		for _, name := range names {
+			upstreamBlocks = append(upstreamBlocks, uuids[name])
+		}
+		return upstreamBlocks
+	}
+
+	for _, operation := range operations {
+		tflog.Debug(ctx, "Changing block of pipeline", map[string]any{"pipeline_uuid": pipelineUUID, "operation": operation.String()})
+
+		uuid := uuids[operation.name]
+		switch operation.action {
+		case dagDetach:
+			blockRequest := makeBlockRequestFromBlock(currentByName[operation.name])
+			blockRequest.UpstreamBlocks = operation.upstreamBlocks
+			_, err = client.BlockAPI().UpdateBlock(ctx, &pipelineUUID, &uuid, &mageai.UpdateBlockRequest{Block: blockRequest})
+		case dagDelete:
+			err = client.BlockAPI().DeleteBlock(ctx, &pipelineUUID, &uuid)
+			if mageai.IsNotFound(err) {
+				err = nil
+			}
+			delete(uuids, operation.name)
+		case dagCreate:
+			block := desired[operation.name]
+			createBlockResponse, createErr := client.BlockAPI().CreateBlock(ctx, &pipelineUUID, &mageai.CreateBlockRequest{
+				Block: mageai.BlockRequest{
+					Content:        block.content,
+					Language:       block.language,
+					Name:           block.name,
+					Type:           mageai.BlockType(block.blockType),
+					UpstreamBlocks: upstreamUUIDs(block.upstreamBlocks),
+				},
+			})
+			if err = createErr; err == nil {
+				uuids[operation.name] = createBlockResponse.Block.UUID
+			}
+		case dagUpdate:
+			block := desired[operation.name]
+			blockRequest := makeBlockRequestFromBlock(currentByName[operation.name])
+			if !block.ignoreContent {
+				blockRequest.Content = block.content
+			}
+			if block.language != "" {
+				blockRequest.Language = block.language
+			}
+			blockRequest.UpstreamBlocks = upstreamUUIDs(block.upstreamBlocks)
+			_, err = client.BlockAPI().UpdateBlock(ctx, &pipelineUUID, &uuid, &mageai.UpdateBlockRequest{Block: blockRequest})
+		}
+
+		if err != nil {
+			diags.Append(newClientErrorDiagnostic(
+				fmt.Sprintf("Error changing block %q", operation.name),
+				fmt.Sprintf("Could not %s block %q, unexpected error: ", operation.action, operation.name),
+				err,
+			))
+			return diags
+		}
+	}
+	return diags
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *PipelineDAGResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected mageai.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = pd.client
+}
+
+// ImportState imports all the blocks of a pipeline using the pipeline UUID.
+func (r *PipelineDAGResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("pipeline_uuid"), req, resp)
+	importProject(ctx, r.client, resp)
+}
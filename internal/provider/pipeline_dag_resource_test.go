@@ -0,0 +1,525 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	frameworkresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func TestAccPipelineDAGResource(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccPipelineDAGResourceConfig(`
+    load = {
+      type            = "data_loader"
+      upstream_blocks = ["export"]
+    }
+    export = {
+      type            = "data_exporter"
+      upstream_blocks = ["load"]
+    }
+`),
+				ExpectError: regexp.MustCompile(`Block\s+"export"\s+\(data_exporter\)\s+cannot\s+be\s+an\s+upstream\s+block\s+of\s+block\s+"load"`),
+			},
+			{
+				Config: testAccProviderConfig(server) + testAccPipelineDAGResourceConfig(`
+    load = {
+      type            = "data_loader"
+      upstream_blocks = ["missing"]
+    }
+`),
+				ExpectError: regexp.MustCompile(`Upstream\s+block\s+"missing"\s+of\s+block\s+"load"\s+is\s+not\s+one\s+of\s+the\s+blocks`),
+			},
+			{
+				Config: testAccProviderConfig(server) + testAccPipelineDAGResourceConfig(`
+    load = {
+      type    = "data_loader"
+      content = "print('load')"
+    }
+    transform = {
+      type            = "transformer"
+      content         = "print('transform')"
+      upstream_blocks = ["load"]
+    }
+    export = {
+      type            = "data_exporter"
+      content         = "print('export')"
+      upstream_blocks = ["transform"]
+    }
+    notes = {
+      type     = "markdown"
+      content  = "# Notes"
+      language = "markdown"
+    }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_pipeline_dag.test", "blocks.%", "4"),
+					resource.TestCheckResourceAttr("mageai_pipeline_dag.test", "blocks.load.uuid", "load"),
+					resource.TestCheckResourceAttr("mageai_pipeline_dag.test", "blocks.load.language", "python"),
+					resource.TestCheckResourceAttr("mageai_pipeline_dag.test", "blocks.load.status", "not_executed"),
+					resource.TestCheckTypeSetElemAttr("mageai_pipeline_dag.test", "blocks.load.downstream_blocks.*", "transform"),
+					resource.TestCheckNoResourceAttr("mageai_pipeline_dag.test", "blocks.load.upstream_blocks"),
+					resource.TestCheckTypeSetElemAttr("mageai_pipeline_dag.test", "blocks.export.upstream_blocks.*", "transform"),
+					resource.TestCheckResourceAttr("mageai_pipeline_dag.test", "blocks.export.downstream_blocks.#", "0"),
+					testAccCheckPipelineUpstreamBlocks(server, "example_pipeline", map[string][]string{
+						"load":      {},
+						"transform": {"load"},
+						"export":    {"transform"},
+						"notes":     {},
+					}),
+				),
+			},
+			{
+				Config: testAccProviderConfig(server) + testAccPipelineDAGResourceConfig(`
+    load = {
+      type    = "data_loader"
+      content = "print('load')"
+    }
+    clean = {
+      type            = "transformer"
+      content         = "print('clean')"
+      upstream_blocks = ["load"]
+    }
+    export = {
+      type            = "data_exporter"
+      content         = "print('export to the warehouse')"
+      upstream_blocks = ["clean"]
+    }
+    notes = {
+      type     = "markdown"
+      content  = "# Notes"
+      language = "markdown"
+    }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_pipeline_dag.test", "blocks.%", "4"),
+					resource.TestCheckNoResourceAttr("mageai_pipeline_dag.test", "blocks.transform.uuid"),
+					resource.TestCheckResourceAttr("mageai_pipeline_dag.test", "blocks.clean.uuid", "clean"),
+					resource.TestCheckTypeSetElemAttr("mageai_pipeline_dag.test", "blocks.load.downstream_blocks.*", "clean"),
+					resource.TestCheckResourceAttr("mageai_pipeline_dag.test", "blocks.export.content", "print('export to the warehouse')"),
+					resource.TestCheckResourceAttr("mageai_pipeline_dag.test", "blocks.export.status", "updated"),
+					testAccCheckPipelineUpstreamBlocks(server, "example_pipeline", map[string][]string{
+						"load":   {},
+						"clean":  {"load"},
+						"export": {"clean"},
+						"notes":  {},
+					}),
+				),
+			},
+			{
+				ResourceName:                         "mageai_pipeline_dag.test",
+				ImportState:                          true,
+				ImportStateId:                        "example_pipeline",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "pipeline_uuid",
+			},
+			{
+				PreConfig: func() {
+					server.DeleteBlock("example_pipeline", "export")
+				},
+				Config: testAccProviderConfig(server) + testAccPipelineDAGResourceConfig(`
+    load = {
+      type    = "data_loader"
+      content = "print('load')"
+    }
+    clean = {
+      type            = "custom"
+      content         = "print('clean')"
+      upstream_blocks = ["load"]
+    }
+    export = {
+      type            = "data_exporter"
+      content         = "print('export to the warehouse')"
+      upstream_blocks = ["clean"]
+    }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_pipeline_dag.test", "blocks.%", "3"),
+					resource.TestCheckResourceAttr("mageai_pipeline_dag.test", "blocks.clean.type", "custom"),
+					resource.TestCheckResourceAttr("mageai_pipeline_dag.test", "blocks.export.uuid", "export"),
+					testAccCheckPipelineUpstreamBlocks(server, "example_pipeline", map[string][]string{
+						"load":   {},
+						"clean":  {"load"},
+						"export": {"clean"},
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPipelineDAGResourceTemplateContent(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.SetBlockTemplate("@data_loader\ndef load_data(*args, **kwargs):\n    pass\n")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccPipelineDAGResourceConfig(`
+    load = {
+      type = "data_loader"
+    }
+    export = {
+      type            = "data_exporter"
+      content         = "print('export')"
+      upstream_blocks = ["load"]
+    }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("mageai_pipeline_dag.test", "blocks.load.content"),
+					resource.TestCheckResourceAttr("mageai_pipeline_dag.test", "blocks.export.content", "print('export')"),
+				),
+			},
+			// The template written by Mage AI is not a change
+			{
+				Config: testAccProviderConfig(server) + testAccPipelineDAGResourceConfig(`
+    load = {
+      type = "data_loader"
+    }
+    export = {
+      type            = "data_exporter"
+      content         = "print('export')"
+      upstream_blocks = ["load"]
+    }
+`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccPipelineDAGResourceConfig(blocks string) string {
+	return fmt.Sprintf(`
+resource "mageai_pipeline" "test" {
+  name = "example_pipeline"
+}
+
+resource "mageai_pipeline_dag" "test" {
+  pipeline_uuid = mageai_pipeline.test.uuid
+
+  blocks = {%s  }
+}
+`, blocks)
+}
+
+// testAccCheckPipelineUpstreamBlocks checks that the pipeline has exactly the
+// blocks, with these upstream blocks.
+func testAccCheckPipelineUpstreamBlocks(server *mageaitest.Server, pipelineUUID string, expected map[string][]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		pipeline, ok := server.Pipeline(pipelineUUID)
+		if !ok {
+			return fmt.Errorf("pipeline %s does not exist", pipelineUUID)
+		}
+
+		if len(pipeline.Blocks) != len(expected) {
+			return fmt.Errorf("expected %d blocks, got %d", len(expected), len(pipeline.Blocks))
+		}
+
+		for _, block := range pipeline.Blocks {
+			expectedUpstreamBlocks, ok := expected[block.UUID]
+			if !ok {
+				return fmt.Errorf("unexpected block %s", block.UUID)
+			}
+
+			upstreamBlocks := slices.Sorted(slices.Values(block.UpstreamBlocks))
+			if !slices.Equal(upstreamBlocks, slices.Sorted(slices.Values(expectedUpstreamBlocks))) {
+				return fmt.Errorf("expected upstream blocks %v of block %s, got %v", expectedUpstreamBlocks, block.UUID, upstreamBlocks)
+			}
+		}
+		return nil
+	}
+}
+
+func TestPlanPipelineDAG(t *testing.T) {
+	current := []mageai.Block{
+		{Name: "load", UUID: "load", Type: "data_loader", Content: "load", DownstreamBlocks: []string{"clean"}},
+		{Name: "clean", UUID: "clean", Type: "transformer", Content: "clean", UpstreamBlocks: []string{"load"}, DownstreamBlocks: []string{"transform"}},
+		{Name: "transform", UUID: "transform", Type: "transformer", Content: "transform", UpstreamBlocks: []string{"clean"}, DownstreamBlocks: []string{"export"}},
+		{Name: "export", UUID: "export", Type: "data_exporter", Content: "export", UpstreamBlocks: []string{"transform"}},
+		{Name: "notes", UUID: "notes", Type: "markdown", Content: "notes"},
+	}
+
+	unchanged := map[string]dagBlock{
+		"load":      {blockType: "data_loader", content: "load"},
+		"clean":     {blockType: "transformer", content: "clean", upstreamBlocks: []string{"load"}},
+		"transform": {blockType: "transformer", content: "transform", upstreamBlocks: []string{"clean"}},
+		"export":    {blockType: "data_exporter", content: "export", upstreamBlocks: []string{"transform"}},
+		"notes":     {blockType: "markdown", content: "notes"},
+	}
+
+	desiredWith := func(changes map[string]*dagBlock) map[string]dagBlock {
+		desired := map[string]dagBlock{}
+		for name, block := range unchanged {
+			block.name = name
+			desired[name] = block
+		}
+		for name, block := range changes {
+			if block == nil {
+				delete(desired, name)
+				continue
+			}
+			block.name = name
+			desired[name] = *block
+		}
+		return desired
+	}
+
+	testCases := map[string]struct {
+		current            []mageai.Block
+		desired            map[string]dagBlock
+		expectedOperations []string
+	}{
+		"create": {
+			desired: desiredWith(nil),
+			expectedOperations: []string{
+				"create load",
+				"create clean",
+				"create transform",
+				"create export",
+				"create notes",
+			},
+		},
+		"unchanged": {
+			current: current,
+			desired: desiredWith(map[string]*dagBlock{
+				"notes": {blockType: "markdown", content: "notes\n\n"},
+			}),
+			expectedOperations: []string{},
+		},
+		"remove a block between two blocks": {
+			current: current,
+			desired: desiredWith(map[string]*dagBlock{
+				"clean":     nil,
+				"transform": {blockType: "transformer", content: "transform", upstreamBlocks: []string{"load"}},
+			}),
+			expectedOperations: []string{
+				"detach transform from all but []",
+				"delete clean",
+				"update transform",
+			},
+		},
+		"unconfigured content": {
+			current: current,
+			desired: desiredWith(map[string]*dagBlock{
+				"notes": {blockType: "markdown", ignoreContent: true},
+			}),
+			expectedOperations: []string{},
+		},
+		"replace a block": {
+			current: current,
+			desired: desiredWith(map[string]*dagBlock{
+				"clean": {blockType: "custom", content: "clean", upstreamBlocks: []string{"load"}},
+			}),
+			expectedOperations: []string{
+				"detach transform from all but []",
+				"delete clean",
+				"create clean",
+				"update transform",
+			},
+		},
+		"update content and upstream blocks": {
+			current: current,
+			desired: desiredWith(map[string]*dagBlock{
+				"export": {blockType: "data_exporter", content: "export", upstreamBlocks: []string{"clean", "transform"}},
+				"load":   {blockType: "data_loader", content: "load more"},
+			}),
+			expectedOperations: []string{
+				"update load",
+				"update export",
+			},
+		},
+		"delete": {
+			current: current,
+			expectedOperations: []string{
+				"delete notes",
+				"delete export",
+				"delete transform",
+				"delete clean",
+				"delete load",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			operations, err := planPipelineDAG(testCase.current, testCase.desired)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			planned := []string{}
+			for _, operation := range operations {
+				planned = append(planned, operation.String())
+			}
+			if !slices.Equal(planned, testCase.expectedOperations) {
+				t.Errorf("expected operations:\n%s\ngot:\n%s", strings.Join(testCase.expectedOperations, "\n"), strings.Join(planned, "\n"))
+			}
+		})
+	}
+}
+
+func TestPlanPipelineDAGUnmanagedBlocks(t *testing.T) {
+	current := []mageai.Block{
+		{Name: "load", UUID: "load", Type: "data_loader", DownstreamBlocks: []string{"export", "report"}},
+		{Name: "export", UUID: "export", Type: "data_exporter", UpstreamBlocks: []string{"load"}},
+		{Name: "report", UUID: "report", Type: "custom", UpstreamBlocks: []string{"load"}, DownstreamBlocks: []string{"notify"}},
+		{Name: "notify", UUID: "notify", Type: "custom", UpstreamBlocks: []string{"report"}},
+	}
+
+	managed := func(block mageai.Block) bool { return block.UUID == "load" || block.UUID == "export" }
+	operations, err := planPipelineDAG(current, unmanagedDAGBlocks(current, managed))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	planned := []string{}
+	for _, operation := range operations {
+		planned = append(planned, operation.String())
+	}
+
+	expectedOperations := []string{
+		"detach report from all but []",
+		"delete export",
+		"delete load",
+	}
+	if !slices.Equal(planned, expectedOperations) {
+		t.Errorf("expected operations:\n%s\ngot:\n%s", strings.Join(expectedOperations, "\n"), strings.Join(planned, "\n"))
+	}
+}
+
+func TestPipelineDAGResourceDeleteKeepsUnmanagedBlocks(t *testing.T) {
+	ctx := context.Background()
+	server := mageaitest.NewServer(t)
+
+	client, err := mageai.New(&mageai.ClientConfig{ApiKey: mageaitest.APIKey, Host: server.URL})
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	pipelineUUID := "example_pipeline"
+	_, err = client.PipelineAPI().CreatePipeline(ctx, &mageai.CreatePipelineRequest{
+		Pipeline: mageai.PipelineRequest{Name: pipelineUUID, Type: mageai.PipelineType("python")},
+	})
+	if err != nil {
+		t.Fatalf("creating pipeline: %v", err)
+	}
+
+	// The report block is added since the last refresh, and depends on a
+	// block of the resource
+	for _, block := range []mageai.BlockRequest{
+		{Name: "load", Type: "data_loader"},
+		{Name: "export", Type: "data_exporter", UpstreamBlocks: []string{"load"}},
+		{Name: "report", Type: "custom", UpstreamBlocks: []string{"load"}},
+	} {
+		if _, err := client.BlockAPI().CreateBlock(ctx, &pipelineUUID, &mageai.CreateBlockRequest{Block: block}); err != nil {
+			t.Fatalf("creating block %s: %v", block.Name, err)
+		}
+	}
+
+	blockModel := func(blockType string) PipelineDAGBlockModel {
+		return PipelineDAGBlockModel{
+			Content:          types.StringNull(),
+			DownstreamBlocks: types.SetNull(types.StringType),
+			Language:         types.StringValue("python"),
+			Status:           types.StringNull(),
+			Type:             types.StringValue(blockType),
+			UpstreamBlocks:   types.SetNull(types.StringType),
+			UUID:             types.StringNull(),
+		}
+	}
+
+	r := &PipelineDAGResource{client: client}
+	state := newTestResourceState(t, r, map[string]any{
+		"pipeline_uuid": pipelineUUID,
+		"blocks": map[string]PipelineDAGBlockModel{
+			"load":   blockModel("data_loader"),
+			"export": blockModel("data_exporter"),
+		},
+	})
+
+	resp := &frameworkresource.DeleteResponse{State: state}
+	r.Delete(ctx, frameworkresource.DeleteRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if err := testAccCheckPipelineUpstreamBlocks(server, pipelineUUID, map[string][]string{"report": {}})(nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidateDAGBlocks(t *testing.T) {
+	testCases := map[string]struct {
+		blocks        map[string]dagBlock
+		expectedError string
+	}{
+		"valid": {
+			blocks: map[string]dagBlock{
+				"load":      {blockType: "data_loader"},
+				"transform": {blockType: "transformer", upstreamBlocks: []string{"load"}},
+				"export":    {blockType: "data_exporter", upstreamBlocks: []string{"transform"}},
+				"notes":     {blockType: "markdown"},
+			},
+		},
+		"unknown type": {
+			blocks: map[string]dagBlock{
+				"load":      {},
+				"transform": {blockType: "transformer", upstreamBlocks: []string{"load"}},
+			},
+		},
+		"itself": {
+			blocks: map[string]dagBlock{
+				"load": {blockType: "data_loader", upstreamBlocks: []string{"load"}},
+			},
+			expectedError: `Block "load" cannot be an upstream block of itself.`,
+		},
+		"cycle": {
+			blocks: map[string]dagBlock{
+				"clean":     {blockType: "transformer", upstreamBlocks: []string{"transform"}},
+				"transform": {blockType: "transformer", upstreamBlocks: []string{"clean"}},
+			},
+			expectedError: `the blocks clean, transform form or depend on a cycle`,
+		},
+		"markdown upstream block": {
+			blocks: map[string]dagBlock{
+				"notes":     {blockType: "markdown"},
+				"transform": {blockType: "transformer", upstreamBlocks: []string{"notes"}},
+			},
+			expectedError: `Block "notes" (markdown) cannot be an upstream block of block "transform" (transformer): markdown blocks are not run with the pipeline and cannot be upstream blocks.`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diags := validateDAGBlocks(path.Root("blocks"), testCase.blocks)
+
+			if testCase.expectedError == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+
+			if diags.ErrorsCount() != 1 || !strings.Contains(diags.Errors()[0].Detail(), testCase.expectedError) {
+				t.Errorf("expected the error %q, got %v", testCase.expectedError, diags)
+			}
+		})
+	}
+}
@@ -179,6 +179,7 @@ func (d *PipelineDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 				Computed:    true,
 				Description: "Human readable name of the pipeline.",
 			},
+			"project": projectDataSourceAttribute(),
 			"retry_config": schema.SingleNestedAttribute{
 				Computed:    true,
 				Description: "The blocks objects of a pipeline.",
@@ -251,19 +252,21 @@ func (d *PipelineDataSource) Configure(ctx context.Context, req datasource.Confi
 
 // Read refreshes the Terraform state with the latest data.
 func (d *PipelineDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var state PipelineModel
+	var state PipelineDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	readDatabaseResponse, err := d.client.PipelineAPI().ReadPipeline(ctx, state.UUID.ValueStringPointer())
+	client := projectClient(d.client, state.Project)
+	readDatabaseResponse, err := client.PipelineAPI().ReadPipeline(ctx, state.UUID.ValueStringPointer())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
 			"Error getting pipeline",
-			err.Error(),
-		)
+			"",
+			err,
+		))
 		return
 	}
 
@@ -276,7 +279,7 @@ func (d *PipelineDataSource) Read(ctx context.Context, req datasource.ReadReques
 		)
 		return
 	}
-	state = *pipelineState
+	state.PipelineModel = *pipelineState
 
 	// Set state
 	diags := resp.State.Set(ctx, &state)
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func TestAccPipelineDataSource(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccBlockResourceConfig + `
+data "mageai_pipeline" "test" {
+  uuid = mageai_block.test.pipeline_uuid
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mageai_pipeline.test", "name", "example_pipeline"),
+					resource.TestCheckResourceAttr("data.mageai_pipeline.test", "type", "python"),
+					resource.TestCheckResourceAttr("data.mageai_pipeline.test", "blocks.#", "1"),
+					resource.TestCheckResourceAttr("data.mageai_pipeline.test", "blocks.0.uuid", "example_block"),
+					resource.TestCheckResourceAttrPair("data.mageai_pipeline.test", "created_at", "mageai_pipeline.test", "created_at"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPipelineDataSourceNotFound(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "mageai_pipeline" "test" {
+  uuid = "missing_pipeline"
+}
+`,
+				ExpectError: regexp.MustCompile(`Pipeline missing_pipeline does not exist`),
+			},
+		},
+	})
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &PipelineGraphDataSource{}
+	_ datasource.DataSourceWithConfigure = &PipelineGraphDataSource{}
+)
+
+// NewPipelineGraphDataSource is a helper function to simplify the provider implementation.
+func NewPipelineGraphDataSource() datasource.DataSource {
+	return &PipelineGraphDataSource{}
+}
+
+// PipelineGraphDataSource is the data source implementation.
+type PipelineGraphDataSource struct {
+	client mageai.Client
+}
+
+// Metadata returns the data source type name.
+func (d *PipelineGraphDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_graph"
+}
+
+// Schema defines the schema for the data source.
+func (d *PipelineGraphDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		Description: "To render the DAG of the blocks of a pipeline, e.g. to generate diagrams for its documentation. " +
+			"Markdown and scratchpad blocks are not part of the graph since they are not run with the pipeline.",
+		Attributes: map[string]schema.Attribute{
+			"adjacency_json": schema.StringAttribute{
+				Computed:    true,
+				Description: "JSON object with the UUIDs of the downstream blocks of every block, e.g. `{\"load_data\":[\"transform_data\"],\"transform_data\":[]}`.",
+			},
+			"dot": schema.StringAttribute{
+				Computed:    true,
+				Description: "The graph in the Graphviz DOT language.",
+			},
+			"leaves": schema.ListAttribute{
+				Computed:    true,
+				Description: "The UUIDs of the blocks without downstream blocks.",
+				ElementType: types.StringType,
+			},
+			"mermaid": schema.StringAttribute{
+				Computed:    true,
+				Description: "The graph as a Mermaid flowchart.",
+			},
+			"pipeline_uuid": schema.StringAttribute{
+				Required:    true,
+				Description: "The UUID of the pipeline.",
+			},
+			"project": projectDataSourceAttribute(),
+			"roots": schema.ListAttribute{
+				Computed:    true,
+				Description: "The UUIDs of the blocks without upstream blocks.",
+				ElementType: types.StringType,
+			},
+			"topological_order": schema.ListAttribute{
+				Computed:    true,
+				Description: "The UUIDs of the blocks, every block coming after its upstream blocks.",
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *PipelineGraphDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected mageai.client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = pd.client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *PipelineGraphDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state PipelineGraphDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := projectClient(d.client, state.Project)
+	readPipelineResponse, err := client.PipelineAPI().ReadPipeline(ctx, state.PipelineUUID.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error getting pipeline",
+			"",
+			err,
+		))
+		return
+	}
+
+	graph := newPipelineGraph(readPipelineResponse.Pipeline.Blocks)
+
+	topologicalOrder, err := graph.topologicalOrder()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting pipeline graph",
+			fmt.Sprintf("The blocks of pipeline %s do not form a DAG: %s.", state.PipelineUUID.ValueString(), err),
+		)
+		return
+	}
+
+	adjacencyJSON, err := graph.adjacencyJSON()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting pipeline graph",
+			err.Error(),
+		)
+		return
+	}
+
+	// Map the graph to the model
+	var diags diag.Diagnostics
+	state.AdjacencyJSON = types.StringValue(adjacencyJSON)
+	state.DOT = types.StringValue(graph.dot(state.PipelineUUID.ValueString()))
+	state.Mermaid = types.StringValue(graph.mermaid())
+	state.Leaves, diags = types.ListValueFrom(ctx, types.StringType, graph.leaves())
+	resp.Diagnostics.Append(diags...)
+	state.Roots, diags = types.ListValueFrom(ctx, types.StringType, graph.roots())
+	resp.Diagnostics.Append(diags...)
+	state.TopologicalOrder, diags = types.ListValueFrom(ctx, types.StringType, topologicalOrder)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func TestAccPipelineGraphDataSource(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "mageai_pipeline" "test" {
+  name = "example_pipeline"
+}
+
+resource "mageai_block" "loader" {
+  name          = "load_data"
+  pipeline_uuid = mageai_pipeline.test.uuid
+  type          = "data_loader"
+}
+
+resource "mageai_block" "api_loader" {
+  name          = "load_api"
+  pipeline_uuid = mageai_pipeline.test.uuid
+  type          = "data_loader"
+}
+
+resource "mageai_block" "transformer" {
+  name            = "transform_data"
+  pipeline_uuid   = mageai_pipeline.test.uuid
+  type            = "transformer"
+  upstream_blocks = [mageai_block.loader.uuid, mageai_block.api_loader.uuid]
+}
+
+resource "mageai_block" "exporter" {
+  name            = "export_data"
+  pipeline_uuid   = mageai_pipeline.test.uuid
+  type            = "data_exporter"
+  upstream_blocks = [mageai_block.transformer.uuid]
+}
+
+resource "mageai_block" "notes" {
+  name          = "notes"
+  pipeline_uuid = mageai_pipeline.test.uuid
+  type          = "markdown"
+  depends_on    = [mageai_block.exporter]
+}
+
+data "mageai_pipeline_graph" "test" {
+  pipeline_uuid = mageai_pipeline.test.uuid
+  depends_on    = [mageai_block.notes]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mageai_pipeline_graph.test", "adjacency_json", `{"export_data":[],"load_api":["transform_data"],"load_data":["transform_data"],"transform_data":["export_data"]}`),
+					resource.TestCheckResourceAttr("data.mageai_pipeline_graph.test", "leaves.#", "1"),
+					resource.TestCheckResourceAttr("data.mageai_pipeline_graph.test", "leaves.0", "export_data"),
+					resource.TestCheckResourceAttr("data.mageai_pipeline_graph.test", "roots.#", "2"),
+					resource.TestCheckTypeSetElemAttr("data.mageai_pipeline_graph.test", "roots.*", "load_data"),
+					resource.TestCheckTypeSetElemAttr("data.mageai_pipeline_graph.test", "roots.*", "load_api"),
+					resource.TestCheckResourceAttr("data.mageai_pipeline_graph.test", "topological_order.#", "4"),
+					resource.TestCheckResourceAttr("data.mageai_pipeline_graph.test", "topological_order.2", "transform_data"),
+					resource.TestCheckResourceAttr("data.mageai_pipeline_graph.test", "topological_order.3", "export_data"),
+				),
+			},
+		},
+	})
+}
+
+func TestPipelineGraph(t *testing.T) {
+	graph := newPipelineGraph([]mageai.Block{
+		{UUID: "load_data", Type: "data_loader", DownstreamBlocks: []string{"transform_data"}},
+		{UUID: "transform_data", Type: "transformer", UpstreamBlocks: []string{"load_data"}},
+		{UUID: "dbt/models/orders", Type: "dbt", UpstreamBlocks: []string{"transform_data", "missing_block"}},
+		{UUID: "notes", Type: "markdown", UpstreamBlocks: []string{"load_data"}},
+	})
+
+	expectedDOT := `digraph "example_pipeline" {
+  "load_data" [label="load_data (data_loader)"];
+  "transform_data" [label="transform_data (transformer)"];
+  "dbt/models/orders" [label="dbt/models/orders (dbt)"];
+  "load_data" -> "transform_data";
+  "transform_data" -> "dbt/models/orders";
+}
+`
+	if dot := graph.dot("example_pipeline"); dot != expectedDOT {
+		t.Errorf("expected DOT:\n%s\ngot:\n%s", expectedDOT, dot)
+	}
+
+	expectedMermaid := `flowchart TD
+  block0["load_data (data_loader)"]
+  block1["transform_data (transformer)"]
+  block2["dbt/models/orders (dbt)"]
+  block0 --> block1
+  block1 --> block2
+`
+	if mermaid := graph.mermaid(); mermaid != expectedMermaid {
+		t.Errorf("expected Mermaid:\n%s\ngot:\n%s", expectedMermaid, mermaid)
+	}
+
+	order, err := graph.topologicalOrder()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(order) != 3 || order[0] != "load_data" || order[1] != "transform_data" || order[2] != "dbt/models/orders" {
+		t.Errorf("unexpected topological order: %v", order)
+	}
+}
+
+func TestPipelineGraphCycle(t *testing.T) {
+	graph := newPipelineGraph([]mageai.Block{
+		{UUID: "load_data", Type: "data_loader"},
+		{UUID: "transform_data", Type: "transformer", UpstreamBlocks: []string{"load_data", "clean_data"}},
+		{UUID: "clean_data", Type: "transformer", UpstreamBlocks: []string{"transform_data"}},
+	})
+
+	_, err := graph.topologicalOrder()
+	if err == nil || err.Error() != "the blocks transform_data, clean_data form or depend on a cycle" {
+		t.Errorf("expected a cycle error, got %v", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
 )
 
@@ -25,6 +26,27 @@ type PipelineModel struct {
 	VariablesDir             types.String `tfsdk:"variables_dir"`
 }
 
+type PipelineDataSourceModel struct {
+	Project types.String `tfsdk:"project"`
+	PipelineModel
+}
+
+type PipelineResourceModel struct {
+	Project types.String `tfsdk:"project"`
+	PipelineModel
+}
+
+type PipelineGraphDataSourceModel struct {
+	AdjacencyJSON    types.String `tfsdk:"adjacency_json"`
+	DOT              types.String `tfsdk:"dot"`
+	Leaves           types.List   `tfsdk:"leaves"`
+	Mermaid          types.String `tfsdk:"mermaid"`
+	PipelineUUID     types.String `tfsdk:"pipeline_uuid"`
+	Project          types.String `tfsdk:"project"`
+	Roots            types.List   `tfsdk:"roots"`
+	TopologicalOrder types.List   `tfsdk:"topological_order"`
+}
+
 type RetryConfigModel struct {
 	Delay              types.Int32 `tfsdk:"delay"`
 	ExponentialBackoff types.Bool  `tfsdk:"exponential_backoff"`
@@ -85,3 +107,68 @@ func getPipelineModel(ctx context.Context, pipeline mageai.Pipeline) (*PipelineM
 	}
 	return &pipelineState, nil
 }
+
+// makePipelineRequestFromModel builds the pipeline request from the plan.
+// Attributes that are unknown in the plan are not sent, so Mage AI keeps or
+// computes their value.
+func makePipelineRequestFromModel(ctx context.Context, p PipelineModel) (*mageai.PipelineRequest, error) {
+	pipelineRequest := &mageai.PipelineRequest{
+		Name: p.Name.ValueString(),
+		Type: mageai.PipelineType(p.Type.ValueString()),
+	}
+
+	if isKnown(p.CacheBlockOutputInMemory) {
+		pipelineRequest.CacheBlockOutputInMemory = p.CacheBlockOutputInMemory.ValueBoolPointer()
+	}
+
+	if isKnown(p.Description) {
+		pipelineRequest.Description = p.Description.ValueStringPointer()
+	}
+
+	if isKnown(p.ExecutorCount) {
+		pipelineRequest.ExecutorCount = p.ExecutorCount.ValueInt32Pointer()
+	}
+
+	if isKnown(p.RetryConfig) {
+		retryConfig, err := convertRetryConfigObjectToModel(ctx, p.RetryConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error converting pipeline retry_config: %v", err)
+		}
+		pipelineRequest.RetryConfig = retryConfig
+	}
+
+	if isKnown(p.RunPipelineInOneProcess) {
+		pipelineRequest.RunPipelineInOneProcess = p.RunPipelineInOneProcess.ValueBoolPointer()
+	}
+
+	if isKnown(p.Tags) {
+		tags := make([]string, 0, len(p.Tags.Elements()))
+		diags := p.Tags.ElementsAs(ctx, &tags, false)
+		if diags.HasError() {
+			return nil, fmt.Errorf("error converting pipeline tags: %v", diags.Errors())
+		}
+		pipelineRequest.Tags = &tags
+	}
+	return pipelineRequest, nil
+}
+
+func convertRetryConfigObjectToModel(ctx context.Context, retryConfigObject basetypes.ObjectValue) (*mageai.RetryConfig, error) {
+	retryConfigModel := RetryConfigModel{}
+	diags := retryConfigObject.As(ctx, &retryConfigModel, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})
+	if diags.HasError() {
+		return nil, fmt.Errorf("could not get retry_config, unexpected error: %v", diags.Errors())
+	}
+
+	retryConfig := &mageai.RetryConfig{
+		Delay:              retryConfigModel.Delay.ValueInt32(),
+		ExponentialBackoff: retryConfigModel.ExponentialBackoff.ValueBool(),
+		MaxDelay:           retryConfigModel.MaxDelay.ValueInt32(),
+		Retries:            retryConfigModel.Retries.ValueInt32(),
+	}
+	return retryConfig, nil
+}
+
+// isKnown reports whether the value is set in the configuration or the plan.
+func isKnown(value attr.Value) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
@@ -4,17 +4,21 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
 )
 
@@ -22,7 +26,7 @@ import (
 var (
 	_ resource.Resource                = &PipelineResource{}
 	_ resource.ResourceWithImportState = &PipelineResource{}
-	_ resource.ResourceWithImportState = &PipelineResource{}
+	_ resource.ResourceWithModifyPlan  = &PipelineResource{}
 )
 
 // NewPipelineResource is a helper function to simplify the provider implementation.
@@ -32,7 +36,8 @@ func NewPipelineResource() resource.Resource {
 
 // PipelineResource defines the resource implementation.
 type PipelineResource struct {
-	client mageai.Client
+	client             mageai.Client
+	serverVersionCheck string
 }
 
 // Metadata returns the resource type name.
@@ -49,7 +54,9 @@ func (r *PipelineResource) Schema(ctx context.Context, req resource.SchemaReques
 			"blocks": schema.ListNestedAttribute{
 				Computed:    true,
 				Description: "The blocks objects of a pipeline.",
-				Default:     listdefault.StaticValue(types.ListValueMust(BlockModel{}.GetAttrType(), []attr.Value{})),
+				PlanModifiers: []planmodifier.List{
+					useStateOrEmptyList(),
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"all_upstream_blocks_executed": schema.BoolAttribute{
@@ -171,7 +178,11 @@ func (r *PipelineResource) Schema(ctx context.Context, req resource.SchemaReques
 			},
 			"cache_block_output_in_memory": schema.BoolAttribute{
 				Computed:    true,
-				Description: "The cache_block_output_in_memory.",
+				Optional:    true,
+				Description: "Whether to cache the output of the blocks in memory instead of writing it to disk. Only used when `run_pipeline_in_one_process` is `true`.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"created_at": schema.StringAttribute{
 				Computed:    true,
@@ -179,11 +190,22 @@ func (r *PipelineResource) Schema(ctx context.Context, req resource.SchemaReques
 			},
 			"description": schema.StringAttribute{
 				Computed:    true,
-				Description: "The description.",
+				Optional:    true,
+				Description: "The description of the pipeline.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"executor_count": schema.Int32Attribute{
 				Computed:    true,
-				Description: "The executor count.",
+				Optional:    true,
+				Description: "The number of executors to run the pipeline with. Only used by `streaming` pipelines.",
+				PlanModifiers: []planmodifier.Int32{
+					int32planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
 			},
 			"name": schema.StringAttribute{
 				Required:    true,
@@ -192,36 +214,65 @@ func (r *PipelineResource) Schema(ctx context.Context, req resource.SchemaReques
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"project": projectResourceAttribute(),
 			"retry_config": schema.SingleNestedAttribute{
 				Computed:    true,
-				Description: "The blocks objects of a pipeline.",
+				Optional:    true,
+				Description: "The retry configuration applied to the blocks of the pipeline.",
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
 				Attributes: map[string]schema.Attribute{
 					"delay": schema.Int32Attribute{
 						Computed:    true,
-						Description: "The type of template part.",
+						Optional:    true,
+						Description: "Initial delay (in seconds) before retry. If exponential_backoff is true, the delay time is multiplied by 2 for the next retry.",
+						PlanModifiers: []planmodifier.Int32{
+							int32planmodifier.UseStateForUnknown(),
+						},
 					},
 					"exponential_backoff": schema.BoolAttribute{
 						Computed:    true,
-						Description: "The type of template part.",
+						Optional:    true,
+						Description: "Whether to use exponential backoff retry.",
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+						},
 					},
 					"max_delay": schema.Int32Attribute{
 						Computed:    true,
-						Description: "The type of template part.",
+						Optional:    true,
+						Description: "Maximum time between the first attempt and the last retry.",
+						PlanModifiers: []planmodifier.Int32{
+							int32planmodifier.UseStateForUnknown(),
+						},
 					},
 					"retries": schema.Int32Attribute{
 						Computed:    true,
-						Description: "The type of template part.",
+						Optional:    true,
+						Description: "Number of retry times.",
+						PlanModifiers: []planmodifier.Int32{
+							int32planmodifier.UseStateForUnknown(),
+						},
 					},
 				},
 			},
 			"run_pipeline_in_one_process": schema.BoolAttribute{
 				Computed:    true,
-				Description: "The bool value for run_pipeline_in_one_process.",
+				Optional:    true,
+				Description: "Whether to run all the blocks of the pipeline in a single process.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"tags": schema.SetAttribute{
 				Computed:    true,
-				Description: "The tags.",
+				Optional:    true,
+				Description: "The tags of the pipeline.",
 				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"type": schema.StringAttribute{
 				Computed:    true,
@@ -251,9 +302,38 @@ func (r *PipelineResource) Schema(ctx context.Context, req resource.SchemaReques
 	}
 }
 
+// ModifyPlan checks that the Mage AI server supports the configured
+// attributes, which older servers silently ignore.
+func (r *PipelineResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Do nothing if the resource is being destroyed or the provider is not configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var config PipelineResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requirements := []serverRequirement{}
+	if !config.CacheBlockOutputInMemory.IsNull() {
+		requirements = append(requirements, cacheBlockOutputInMemoryRequirement)
+	}
+	if !config.ExecutorCount.IsNull() {
+		requirements = append(requirements, executorCountRequirement)
+	}
+	if !config.RunPipelineInOneProcess.IsNull() {
+		requirements = append(requirements, runPipelineInOneProcessRequirement)
+	}
+
+	client := projectClient(r.client, config.Project)
+	resp.Diagnostics.Append(checkServerVersion(ctx, client, r.serverVersionCheck, requirements)...)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *PipelineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan PipelineModel
+	var plan PipelineResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -262,24 +342,66 @@ func (r *PipelineResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	// Generate API request body from plan
+	pipelineRequest, err := makePipelineRequestFromModel(ctx, plan.PipelineModel)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating pipeline request",
+			err.Error(),
+		)
+		return
+	}
+
 	createPipelineRequest := &mageai.CreatePipelineRequest{
-		Pipeline: mageai.PipelineRequest{
-			Name: plan.Name.ValueString(),
-			Type: mageai.PipelineType(plan.Type.ValueString()),
-		},
+		Pipeline: *pipelineRequest,
 	}
 
-	createPipelineResponse, err := r.client.PipelineAPI().CreatePipeline(ctx, createPipelineRequest)
+	client := projectClient(r.client, plan.Project)
+	createPipelineResponse, err := client.PipelineAPI().CreatePipeline(ctx, createPipelineRequest)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
 			"Error creating pipeline",
-			"Could not create pipeline, unexpected error: "+err.Error(),
+			"Could not create pipeline, unexpected error: ",
+			err,
+		))
+		return
+	}
+
+	// The created pipeline is saved before its settings are applied, so that
+	// it is tainted and replaced on the next apply if the update fails
+	pipelineModel, err := getPipelineModel(ctx, createPipelineResponse.Pipeline)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting pipeline model",
+			err.Error(),
 		)
 		return
 	}
+	plan.PipelineModel = *pipelineModel
+	plan.Project = projectValue(client)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Mage AI only uses the name and type of the pipeline on creation,
+	// so the remaining settings are applied with an update
+	updatePipelineRequest := &mageai.UpdatePipelineRequest{
+		Pipeline: *pipelineRequest,
+	}
+
+	updatePipelineResponse, err := client.PipelineAPI().UpdatePipeline(ctx, &createPipelineResponse.Pipeline.UUID, updatePipelineRequest)
+	if err != nil {
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error creating pipeline",
+			"Could not update the settings of the created pipeline, unexpected error: ",
+			err,
+		))
+		return
+	}
 
 	// Map response body to schema and populate Computed attribute values
-	pipelineModel, err := getPipelineModel(ctx, createPipelineResponse.Pipeline)
+	pipelineModel, err = getPipelineModel(ctx, updatePipelineResponse.Pipeline)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error getting pipeline model",
@@ -287,7 +409,7 @@ func (r *PipelineResource) Create(ctx context.Context, req resource.CreateReques
 		)
 		return
 	}
-	plan = *pipelineModel
+	plan.PipelineModel = *pipelineModel
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -299,7 +421,7 @@ func (r *PipelineResource) Create(ctx context.Context, req resource.CreateReques
 // Read refreshes the Terraform state with the latest data.
 func (r *PipelineResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// Get current state
-	var state PipelineModel
+	var state PipelineResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -308,12 +430,21 @@ func (r *PipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	// Get refreshed pipeline value from Mage AI
-	readDatabaseResponse, err := r.client.PipelineAPI().ReadPipeline(ctx, state.UUID.ValueStringPointer())
+	client := projectClient(r.client, state.Project)
+	readDatabaseResponse, err := client.PipelineAPI().ReadPipeline(ctx, state.UUID.ValueStringPointer())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		// Remove the resource from state so that Terraform plans to recreate it
+		if mageai.IsNotFound(err) {
+			tflog.Warn(ctx, "The pipeline no longer exists, removing it from state", map[string]any{"uuid": state.UUID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
 			"Error getting pipeline",
-			err.Error(),
-		)
+			"",
+			err,
+		))
 		return
 	}
 
@@ -326,7 +457,7 @@ func (r *PipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 		)
 		return
 	}
-	state = *pipelineModel
+	state.PipelineModel = *pipelineModel
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -337,7 +468,7 @@ func (r *PipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *PipelineResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan PipelineModel
+	var plan PipelineResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -346,19 +477,28 @@ func (r *PipelineResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	// Generate API request body from plan
+	pipelineRequest, err := makePipelineRequestFromModel(ctx, plan.PipelineModel)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating pipeline request",
+			err.Error(),
+		)
+		return
+	}
+
 	updatePipelineRequest := &mageai.UpdatePipelineRequest{
-		Pipeline: mageai.PipelineRequest{
-			Name: plan.Name.ValueString(),
-			Type: mageai.PipelineType(plan.Type.ValueString()),
-		}}
+		Pipeline: *pipelineRequest,
+	}
 
 	// Update existing pipeline
-	updatePipelineResponse, err := r.client.PipelineAPI().UpdatePipeline(ctx, plan.UUID.ValueStringPointer(), updatePipelineRequest)
+	client := projectClient(r.client, plan.Project)
+	updatePipelineResponse, err := client.PipelineAPI().UpdatePipeline(ctx, plan.UUID.ValueStringPointer(), updatePipelineRequest)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
 			"Error updating pipeline",
-			"Could not update pipeline, unexpected error: "+err.Error(),
-		)
+			"Could not update pipeline, unexpected error: ",
+			err,
+		))
 		return
 	}
 
@@ -371,7 +511,7 @@ func (r *PipelineResource) Update(ctx context.Context, req resource.UpdateReques
 		)
 		return
 	}
-	plan = *pipelineModel
+	plan.PipelineModel = *pipelineModel
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -382,7 +522,7 @@ func (r *PipelineResource) Update(ctx context.Context, req resource.UpdateReques
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *PipelineResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state PipelineModel
+	var state PipelineResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -391,12 +531,19 @@ func (r *PipelineResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 
 	// Delete existing pipeline
-	err := r.client.PipelineAPI().DeletePipeline(ctx, state.UUID.ValueStringPointer())
+	client := projectClient(r.client, state.Project)
+	err := client.PipelineAPI().DeletePipeline(ctx, state.UUID.ValueStringPointer())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		// The pipeline is already gone, there is nothing left to delete
+		if mageai.IsNotFound(err) {
+			return
+		}
+
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
 			"Error deleting pipeline",
-			"Could not delete pipeline, unexpected error: "+err.Error(),
-		)
+			"Could not delete pipeline, unexpected error: ",
+			err,
+		))
 		return
 	}
 }
@@ -417,8 +564,10 @@ func (r *PipelineResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 	r.client = pd.client
+	r.serverVersionCheck = pd.serverVersionCheck
 }
 
 func (r *PipelineResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+	importProject(ctx, r.client, resp)
 }
@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func TestAccPipelineResourceSettings(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "mageai_pipeline" "test" {
+  name = "example_pipeline"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "uuid", "example_pipeline"),
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "description", ""),
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "executor_count", "1"),
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "tags.#", "0"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(server) + testAccPipelineResourceSettingsConfig("Loads the example data.", 2, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "description", "Loads the example data."),
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "executor_count", "2"),
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "retry_config.retries", "3"),
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "retry_config.delay", "5"),
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "run_pipeline_in_one_process", "true"),
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "cache_block_output_in_memory", "true"),
+					resource.TestCheckTypeSetElemAttr("mageai_pipeline.test", "tags.*", "example"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(server) + testAccPipelineResourceSettingsConfig("Loads the example data daily.", 1, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "description", "Loads the example data daily."),
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "executor_count", "1"),
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "run_pipeline_in_one_process", "false"),
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "cache_block_output_in_memory", "false"),
+				),
+			},
+			{
+				ResourceName:                         "mageai_pipeline.test",
+				ImportState:                          true,
+				ImportStateId:                        "example_pipeline",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "uuid",
+			},
+		},
+	})
+}
+
+func TestAccPipelineResourceCreateWithSettings(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccPipelineResourceSettingsConfig("Loads the example data.", 2, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "description", "Loads the example data."),
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "executor_count", "2"),
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "retry_config.exponential_backoff", "true"),
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "tags.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPipelineResourceCreateFailedUpdate(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.RejectPipelineUpdates("Invalid executor count.")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccProviderConfig(server) + testAccPipelineResourceSettingsConfig("Loads the example data.", 2, true),
+				ExpectError: regexp.MustCompile(`Could not update the settings of the created pipeline`),
+			},
+			// The created pipeline is tainted and replaced
+			{
+				PreConfig: func() { server.RejectPipelineUpdates("") },
+				Config:    testAccProviderConfig(server) + testAccPipelineResourceSettingsConfig("Loads the example data.", 2, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mageai_pipeline.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "description", "Loads the example data."),
+					resource.TestCheckResourceAttr("mageai_pipeline.test", "executor_count", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPipelineResourceSettingsConfig(description string, executorCount int, inOneProcess bool) string {
+	return fmt.Sprintf(`
+resource "mageai_pipeline" "test" {
+  name                         = "example_pipeline"
+  description                  = %[1]q
+  executor_count               = %[2]d
+  run_pipeline_in_one_process  = %[3]t
+  cache_block_output_in_memory = %[3]t
+  tags                         = ["example", "daily"]
+
+  retry_config = {
+    delay               = 5
+    exponential_backoff = true
+    max_delay           = 60
+    retries             = 3
+  }
+}
+`, description, executorCount, inOneProcess)
+}
+
+func TestPipelineResourceReadRemovesMissingPipeline(t *testing.T) {
+	r := &PipelineResource{client: newNotFoundTestClient(t)}
+	testResourceReadRemovesMissing(t, r, map[string]any{
+		"name": "example_pipeline",
+		"uuid": "example_pipeline",
+	})
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+type PipelineRunModel struct {
+	CompletedAt          types.String `tfsdk:"completed_at"`
+	CreatedAt            types.String `tfsdk:"created_at"`
+	ExecutionDate        types.String `tfsdk:"execution_date"`
+	ID                   types.Int64  `tfsdk:"id"`
+	PipelineScheduleID   types.Int64  `tfsdk:"pipeline_schedule_id"`
+	PipelineScheduleName types.String `tfsdk:"pipeline_schedule_name"`
+	PipelineUUID         types.String `tfsdk:"pipeline_uuid"`
+	StartedAt            types.String `tfsdk:"started_at"`
+	Status               types.String `tfsdk:"status"`
+	UpdatedAt            types.String `tfsdk:"updated_at"`
+	Variables            types.Map    `tfsdk:"variables"`
+}
+
+type PipelineRunResourceModel struct {
+	CompletedAt        types.String   `tfsdk:"completed_at"`
+	CreatedAt          types.String   `tfsdk:"created_at"`
+	ExecutionDate      types.String   `tfsdk:"execution_date"`
+	ID                 types.Int64    `tfsdk:"id"`
+	PipelineScheduleID types.Int64    `tfsdk:"pipeline_schedule_id"`
+	PipelineUUID       types.String   `tfsdk:"pipeline_uuid"`
+	Project            types.String   `tfsdk:"project"`
+	StartedAt          types.String   `tfsdk:"started_at"`
+	Status             types.String   `tfsdk:"status"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+	Triggers           types.Map      `tfsdk:"triggers"`
+	Variables          types.Map      `tfsdk:"variables"`
+	WaitForCompletion  types.Bool     `tfsdk:"wait_for_completion"`
+}
+
+type PipelineRunsDataSourceModel struct {
+	CreatedAfter       types.String       `tfsdk:"created_after"`
+	CreatedBefore      types.String       `tfsdk:"created_before"`
+	PipelineRuns       []PipelineRunModel `tfsdk:"pipeline_runs"`
+	PipelineScheduleID types.Int64        `tfsdk:"pipeline_schedule_id"`
+	PipelineUUID       types.String       `tfsdk:"pipeline_uuid"`
+	Project            types.String       `tfsdk:"project"`
+	Status             types.String       `tfsdk:"status"`
+}
+
+func getPipelineRunModel(ctx context.Context, pipelineRun mageai.PipelineRun) (*PipelineRunModel, error) {
+	variables, err := convertVariablesToStringMap(pipelineRun.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("error getting variables: %w", err)
+	}
+
+	variablesMapValue, diags := types.MapValueFrom(ctx, types.StringType, variables)
+	if diags.HasError() {
+		return nil, fmt.Errorf("error getting variables")
+	}
+
+	pipelineRunState := PipelineRunModel{
+		CompletedAt:          types.StringValue(pipelineRun.CompletedAt),
+		CreatedAt:            types.StringValue(pipelineRun.CreatedAt),
+		ExecutionDate:        types.StringValue(pipelineRun.ExecutionDate),
+		ID:                   types.Int64Value(pipelineRun.ID),
+		PipelineScheduleID:   types.Int64Value(pipelineRun.PipelineScheduleID),
+		PipelineScheduleName: types.StringValue(pipelineRun.PipelineScheduleName),
+		PipelineUUID:         types.StringValue(pipelineRun.PipelineUUID),
+		StartedAt:            types.StringValue(pipelineRun.StartedAt),
+		Status:               types.StringValue(pipelineRun.Status),
+		UpdatedAt:            types.StringValue(pipelineRun.UpdatedAt),
+		Variables:            variablesMapValue,
+	}
+	return &pipelineRunState, nil
+}
+
+// setPipelineRunResourceModel copies the attributes returned by Mage AI into
+// the resource model. The variables are kept as configured, since Mage AI
+// returns them merged with the variables of the pipeline schedule.
+func setPipelineRunResourceModel(model *PipelineRunResourceModel, pipelineRun mageai.PipelineRun) {
+	model.CompletedAt = types.StringValue(pipelineRun.CompletedAt)
+	model.CreatedAt = types.StringValue(pipelineRun.CreatedAt)
+	model.ExecutionDate = types.StringValue(pipelineRun.ExecutionDate)
+	model.ID = types.Int64Value(pipelineRun.ID)
+	model.PipelineScheduleID = types.Int64Value(pipelineRun.PipelineScheduleID)
+	model.PipelineUUID = types.StringValue(pipelineRun.PipelineUUID)
+	model.StartedAt = types.StringValue(pipelineRun.StartedAt)
+	model.Status = types.StringValue(pipelineRun.Status)
+}
+
+func makePipelineRunRequestFromModel(ctx context.Context, p PipelineRunResourceModel) (*mageai.PipelineRunRequest, error) {
+	variables, err := convertVariablesMapToAnyMap(ctx, p.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("error converting pipeline run variables: %v", err)
+	}
+
+	return &mageai.PipelineRunRequest{
+		Variables: variables,
+	}, nil
+}
@@ -0,0 +1,323 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+const defaultPipelineRunCreateTimeout = 30 * time.Minute
+
+// pipelineRunPollInterval is the time between two reads of a pipeline run
+// while waiting for its completion.
+var pipelineRunPollInterval = 10 * time.Second
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource              = &PipelineRunResource{}
+	_ resource.ResourceWithConfigure = &PipelineRunResource{}
+)
+
+// NewPipelineRunResource is a helper function to simplify the provider implementation.
+func NewPipelineRunResource() resource.Resource {
+	return &PipelineRunResource{}
+}
+
+// PipelineRunResource defines the resource implementation.
+type PipelineRunResource struct {
+	client mageai.Client
+}
+
+// Metadata returns the resource type name.
+func (r *PipelineRunResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_run"
+}
+
+// Schema defines the schema for the resource.
+func (r *PipelineRunResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		Description: "Trigger a run of a pipeline through one of its triggers (pipeline schedules), and optionally wait for it to complete. " +
+			"A new run is triggered when `pipeline_schedule_id`, `variables` or `triggers` change. " +
+			"Destroying the resource only removes it from the Terraform state, the run is kept in the history of the pipeline.",
+		Attributes: map[string]schema.Attribute{
+			"completed_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The date and time the run completed.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The created_at.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"execution_date": schema.StringAttribute{
+				Computed:    true,
+				Description: "The execution date of the run.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Unique identifier for the pipeline run.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"pipeline_schedule_id": schema.Int64Attribute{
+				Required:    true,
+				Description: "The ID of the trigger (pipeline schedule) to run the pipeline with.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"pipeline_uuid": schema.StringAttribute{
+				Computed:    true,
+				Description: "The UUID of the pipeline that is run.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project": projectResourceAttribute(),
+			"started_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The date and time the run started.",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "Status of the run: `initial`, `running`, `completed`, `failed`, `cancelled`.",
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create:            true,
+				CreateDescription: "How long to wait for the run to complete when `wait_for_completion` is `true`, e.g. `1h`. Defaults to `30m`.",
+			}),
+			"triggers": schema.MapAttribute{
+				Optional:    true,
+				Description: "Arbitrary values that trigger a new run when they change, e.g. the version of the deployed code.",
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"variables": schema.MapAttribute{
+				Optional:    true,
+				Description: "Runtime variables of the run. They override the variables of the trigger.",
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				Computed:    true,
+				Optional:    true,
+				Description: "Whether to wait for the run to complete. The apply fails if the run fails, is cancelled or does not complete in time. Defaults to `false`.",
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *PipelineRunResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan PipelineRunResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	pipelineRunRequest, err := makePipelineRunRequestFromModel(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating pipeline run",
+			err.Error(),
+		)
+		return
+	}
+
+	client := projectClient(r.client, plan.Project)
+	plan.Project = projectValue(client)
+	createPipelineRunResponse, err := client.PipelineRunAPI().CreatePipelineRun(ctx, plan.PipelineScheduleID.ValueInt64Pointer(), &mageai.CreatePipelineRunRequest{PipelineRun: *pipelineRunRequest})
+	if err != nil {
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error creating pipeline run",
+			"Could not create pipeline run, unexpected error: ",
+			err,
+		))
+		return
+	}
+
+	// Save the run into Terraform state before waiting for it, so that it is
+	// tracked, as tainted, even if it does not complete
+	setPipelineRunResourceModel(&plan, createPipelineRunResponse.PipelineRun)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || !plan.WaitForCompletion.ValueBool() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultPipelineRunCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pipelineRun, diags := r.waitForPipelineRun(ctx, client, createPipelineRunResponse.PipelineRun, createTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	setPipelineRunResourceModel(&plan, pipelineRun)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// waitForPipelineRun reads the pipeline run with the client until it reaches a
+// terminal status or the timeout expires, and returns its last known value. It
+// returns an error diagnostic unless the run completed.
+func (r *PipelineRunResource) waitForPipelineRun(ctx context.Context, client mageai.Client, pipelineRun mageai.PipelineRun, timeout time.Duration) (mageai.PipelineRun, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for !mageai.PipelineRunStatus(pipelineRun.Status).IsTerminal() {
+		tflog.Debug(ctx, "Waiting for the pipeline run to complete", map[string]any{"id": pipelineRun.ID, "status": pipelineRun.Status})
+
+		timer := time.NewTimer(pipelineRunPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			diags.Append(newPipelineRunTimeoutDiagnostic(pipelineRun, timeout))
+			return pipelineRun, diags
+		case <-timer.C:
+		}
+
+		readPipelineRunResponse, err := client.PipelineRunAPI().ReadPipelineRun(ctx, &pipelineRun.ID)
+		if err != nil {
+			// The timeout expired while reading the run
+			if ctx.Err() != nil {
+				diags.Append(newPipelineRunTimeoutDiagnostic(pipelineRun, timeout))
+				return pipelineRun, diags
+			}
+
+			diags.Append(newClientErrorDiagnostic(
+				"Error waiting for pipeline run",
+				"Could not get pipeline run, unexpected error: ",
+				err,
+			))
+			return pipelineRun, diags
+		}
+		pipelineRun = readPipelineRunResponse.PipelineRun
+	}
+
+	if mageai.PipelineRunStatus(pipelineRun.Status) != mageai.CompletedPipelineRunStatus {
+		diags.AddError(
+			"Pipeline run did not complete",
+			fmt.Sprintf("Pipeline run %d of pipeline %s ended with status %q. Check the logs of the run in Mage AI.", pipelineRun.ID, pipelineRun.PipelineUUID, pipelineRun.Status),
+		)
+	}
+	return pipelineRun, diags
+}
+
+func newPipelineRunTimeoutDiagnostic(pipelineRun mageai.PipelineRun, timeout time.Duration) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error waiting for pipeline run",
+		fmt.Sprintf("Pipeline run %d of pipeline %s did not complete within %s, its last status is %q.", pipelineRun.ID, pipelineRun.PipelineUUID, timeout, pipelineRun.Status),
+	)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *PipelineRunResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state PipelineRunResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed pipeline run value from Mage AI
+	client := projectClient(r.client, state.Project)
+	readPipelineRunResponse, err := client.PipelineRunAPI().ReadPipelineRun(ctx, state.ID.ValueInt64Pointer())
+	if err != nil {
+		// Remove the resource from state so that Terraform plans to trigger a new run
+		if mageai.IsNotFound(err) {
+			tflog.Warn(ctx, "The pipeline run no longer exists, removing it from state", map[string]any{"id": state.ID.ValueInt64()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error getting pipeline run",
+			"",
+			err,
+		))
+		return
+	}
+
+	// Overwrite items with refreshed state
+	setPipelineRunResourceModel(&state, readPipelineRunResponse.PipelineRun)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update only changes wait_for_completion and timeouts, every other
+// configurable attribute triggers a new run.
+func (r *PipelineRunResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state PipelineRunResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Timeouts = plan.Timeouts
+	state.WaitForCompletion = plan.WaitForCompletion
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Delete removes the resource from the Terraform state. Mage AI keeps the run
+// in the history of the pipeline.
+func (r *PipelineRunResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *PipelineRunResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected mageai.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = pd.client
+}
@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+// setTestPipelineRunPollInterval shortens the time between two reads of a
+// pipeline run for the duration of the test.
+func setTestPipelineRunPollInterval(t *testing.T) {
+	pollInterval := pipelineRunPollInterval
+	pipelineRunPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() {
+		pipelineRunPollInterval = pollInterval
+	})
+}
+
+func TestAccPipelineRunResource(t *testing.T) {
+	setTestPipelineRunPollInterval(t)
+	server := mageaitest.NewServer(t)
+	server.SetPipelineRunOutcome(mageai.CompletedPipelineRunStatus)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccPipelineRunResourceConfig("v1", `
+  wait_for_completion = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_pipeline_run.test", "id", "1"),
+					resource.TestCheckResourceAttr("mageai_pipeline_run.test", "pipeline_schedule_id", "1"),
+					resource.TestCheckResourceAttr("mageai_pipeline_run.test", "pipeline_uuid", "example_pipeline"),
+					resource.TestCheckResourceAttr("mageai_pipeline_run.test", "status", "completed"),
+					resource.TestCheckResourceAttr("mageai_pipeline_run.test", "variables.%", "1"),
+					resource.TestCheckResourceAttr("mageai_pipeline_run.test", "variables.date", "2024-01-01"),
+					resource.TestCheckResourceAttrSet("mageai_pipeline_run.test", "completed_at"),
+					resource.TestCheckResourceAttrSet("mageai_pipeline_run.test", "started_at"),
+					testAccCheckPipelineRunVariables(server, 1, map[string]any{"date": "2024-01-01", "env": "dev"}),
+				),
+			},
+			{
+				Config: testAccProviderConfig(server) + testAccPipelineRunResourceConfig("v2", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_pipeline_run.test", "id", "2"),
+					resource.TestCheckResourceAttr("mageai_pipeline_run.test", "status", "initial"),
+					resource.TestCheckResourceAttr("mageai_pipeline_run.test", "wait_for_completion", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPipelineRunResourceFailed(t *testing.T) {
+	setTestPipelineRunPollInterval(t)
+	server := mageaitest.NewServer(t)
+	server.SetPipelineRunOutcome(mageai.FailedPipelineRunStatus)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccPipelineRunResourceConfig("v1", `
+  wait_for_completion = true
+`),
+				ExpectError: regexp.MustCompile(`Pipeline\s+run\s+1\s+of\s+pipeline\s+example_pipeline\s+ended\s+with\s+status\s+"failed"`),
+			},
+		},
+	})
+}
+
+func TestAccPipelineRunResourceTimeout(t *testing.T) {
+	setTestPipelineRunPollInterval(t)
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccPipelineRunResourceConfig("v1", `
+  wait_for_completion = true
+
+  timeouts = {
+    create = "1s"
+  }
+`),
+				ExpectError: regexp.MustCompile(`did\s+not\s+complete\s+within\s+1s,\s+its\s+last\s+status\s+is\s+"running"`),
+			},
+		},
+	})
+}
+
+func testAccCheckPipelineRunVariables(server *mageaitest.Server, id int64, expectedVariables map[string]any) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		pipelineRun, ok := server.PipelineRun(id)
+		if !ok {
+			return fmt.Errorf("pipeline run %d not found", id)
+		}
+
+		if fmt.Sprint(pipelineRun.Variables) != fmt.Sprint(expectedVariables) {
+			return fmt.Errorf("expected variables %v, got %v", expectedVariables, pipelineRun.Variables)
+		}
+		return nil
+	}
+}
+
+func testAccPipelineRunResourceConfig(version, settings string) string {
+	return fmt.Sprintf(`
+resource "mageai_pipeline" "test" {
+  name = "example_pipeline"
+}
+
+resource "mageai_pipeline_schedule" "test" {
+  name          = "example_trigger"
+  pipeline_uuid = mageai_pipeline.test.uuid
+  schedule_type = "api"
+  status        = "active"
+
+  variables = {
+    env = "dev"
+  }
+}
+
+resource "mageai_pipeline_run" "test" {
+  pipeline_schedule_id = mageai_pipeline_schedule.test.id
+
+  triggers = {
+    version = %[1]q
+  }
+
+  variables = {
+    date = "2024-01-01"
+  }
+%[2]s}
+`, version, settings)
+}
+
+func TestPipelineRunResourceReadRemovesMissingPipelineRun(t *testing.T) {
+	r := &PipelineRunResource{client: newNotFoundTestClient(t)}
+	testResourceReadRemovesMissing(t, r, map[string]any{
+		"id":                   int64(1),
+		"pipeline_schedule_id": int64(1),
+	})
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &PipelineRunsDataSource{}
+	_ datasource.DataSourceWithConfigure = &PipelineRunsDataSource{}
+)
+
+// NewPipelineRunsDataSource is a helper function to simplify the provider implementation.
+func NewPipelineRunsDataSource() datasource.DataSource {
+	return &PipelineRunsDataSource{}
+}
+
+// PipelineRunsDataSource is the data source implementation.
+type PipelineRunsDataSource struct {
+	client mageai.Client
+}
+
+// Metadata returns the data source type name.
+func (d *PipelineRunsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_runs"
+}
+
+// Schema defines the schema for the data source.
+func (d *PipelineRunsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		Description: "To retrieve the pipeline runs, optionally filtered by pipeline, trigger, status and creation date.",
+		Attributes: map[string]schema.Attribute{
+			"created_after": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the runs created at or after this RFC 3339 timestamp, e.g. `2024-01-02T15:04:05Z`.",
+				Validators: []validator.String{
+					isRFC3339(),
+				},
+			},
+			"created_before": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the runs created at or before this RFC 3339 timestamp, e.g. `2024-01-02T15:04:05Z`.",
+				Validators: []validator.String{
+					isRFC3339(),
+				},
+			},
+			"pipeline_runs": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The pipeline runs.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"completed_at": schema.StringAttribute{
+							Computed:    true,
+							Description: "The date and time the run completed.",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:    true,
+							Description: "The created_at.",
+						},
+						"execution_date": schema.StringAttribute{
+							Computed:    true,
+							Description: "The execution date of the run.",
+						},
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Unique identifier for the pipeline run.",
+						},
+						"pipeline_schedule_id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The ID of the trigger (pipeline schedule) of the run.",
+						},
+						"pipeline_schedule_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the trigger (pipeline schedule) of the run.",
+						},
+						"pipeline_uuid": schema.StringAttribute{
+							Computed:    true,
+							Description: "The UUID of the pipeline that is run.",
+						},
+						"started_at": schema.StringAttribute{
+							Computed:    true,
+							Description: "The date and time the run started.",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "Status of the run: `initial`, `running`, `completed`, `failed`, `cancelled`.",
+						},
+						"updated_at": schema.StringAttribute{
+							Computed:    true,
+							Description: "The updated_at value.",
+						},
+						"variables": schema.MapAttribute{
+							Computed:    true,
+							Description: "Runtime variables of the run.",
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+			"pipeline_schedule_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Only return the runs of the trigger (pipeline schedule) with this ID.",
+			},
+			"pipeline_uuid": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the runs of the pipeline with this UUID.",
+			},
+			"project": projectDataSourceAttribute(),
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the runs with this status: `initial`, `running`, `completed`, `failed`, `cancelled`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"cancelled", "completed", "failed", "initial", "running"}...),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *PipelineRunsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected mageai.client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = pd.client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *PipelineRunsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state PipelineRunsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := mageai.PipelineRunsFilter{
+		PipelineScheduleID: state.PipelineScheduleID.ValueInt64(),
+		PipelineUUID:       state.PipelineUUID.ValueString(),
+		Status:             mageai.PipelineRunStatus(state.Status.ValueString()),
+	}
+
+	// The timestamps are validated by the schema
+	if !state.CreatedAfter.IsNull() {
+		filter.CreatedAfter, _ = time.Parse(time.RFC3339, state.CreatedAfter.ValueString())
+	}
+
+	if !state.CreatedBefore.IsNull() {
+		filter.CreatedBefore, _ = time.Parse(time.RFC3339, state.CreatedBefore.ValueString())
+	}
+
+	client := projectClient(d.client, state.Project)
+	readPipelineRunsResponse, err := client.PipelineRunAPI().ReadPipelineRuns(ctx, &filter)
+	if err != nil {
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error getting pipeline runs",
+			"",
+			err,
+		))
+		return
+	}
+
+	// Map response body to model
+	state.PipelineRuns = []PipelineRunModel{}
+	for _, pipelineRun := range readPipelineRunsResponse.PipelineRuns {
+		pipelineRunState, err := getPipelineRunModel(ctx, pipelineRun)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error getting pipeline runs",
+				err.Error(),
+			)
+			return
+		}
+		state.PipelineRuns = append(state.PipelineRuns, *pipelineRunState)
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
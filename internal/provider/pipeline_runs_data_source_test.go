@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func TestAccPipelineRunsDataSource(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.AddPipelineRun(mageai.PipelineRun{CreatedAt: "2024-01-01 00:00:00.000000+00:00", PipelineScheduleID: 1, PipelineUUID: "example_pipeline", Status: "completed", Variables: map[string]any{"env": "dev"}})
+	server.AddPipelineRun(mageai.PipelineRun{CreatedAt: "2024-02-01 00:00:00.000000+00:00", PipelineScheduleID: 1, PipelineUUID: "example_pipeline", Status: "failed"})
+	server.AddPipelineRun(mageai.PipelineRun{CreatedAt: "2024-03-01 00:00:00.000000+00:00", PipelineScheduleID: 2, PipelineUUID: "other_pipeline", Status: "failed"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "mageai_pipeline_runs" "test" {
+  created_after = "2024-01-01"
+}
+`,
+				ExpectError: regexp.MustCompile(`value\s+must\s+be\s+an\s+RFC\s+3339\s+timestamp`),
+			},
+			{
+				Config: testAccProviderConfig(server) + `
+data "mageai_pipeline_runs" "all" {}
+
+data "mageai_pipeline_runs" "pipeline" {
+  pipeline_uuid = "example_pipeline"
+}
+
+data "mageai_pipeline_runs" "failed" {
+  status = "failed"
+}
+
+data "mageai_pipeline_runs" "range" {
+  created_after  = "2024-01-15T00:00:00Z"
+  created_before = "2024-02-15T00:00:00Z"
+}
+
+data "mageai_pipeline_runs" "none" {
+  pipeline_schedule_id = 3
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mageai_pipeline_runs.all", "pipeline_runs.#", "3"),
+					resource.TestCheckResourceAttr("data.mageai_pipeline_runs.pipeline", "pipeline_runs.#", "2"),
+					resource.TestCheckResourceAttr("data.mageai_pipeline_runs.pipeline", "pipeline_runs.0.id", "1"),
+					resource.TestCheckResourceAttr("data.mageai_pipeline_runs.pipeline", "pipeline_runs.0.status", "completed"),
+					resource.TestCheckResourceAttr("data.mageai_pipeline_runs.pipeline", "pipeline_runs.0.variables.env", "dev"),
+					resource.TestCheckResourceAttr("data.mageai_pipeline_runs.failed", "pipeline_runs.#", "2"),
+					resource.TestCheckResourceAttr("data.mageai_pipeline_runs.range", "pipeline_runs.#", "1"),
+					resource.TestCheckResourceAttr("data.mageai_pipeline_runs.range", "pipeline_runs.0.id", "2"),
+					resource.TestCheckResourceAttr("data.mageai_pipeline_runs.none", "pipeline_runs.#", "0"),
+				),
+			},
+		},
+	})
+}
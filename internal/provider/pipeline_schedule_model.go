@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+type PipelineScheduleModel struct {
+	CreatedAt        types.String `tfsdk:"created_at"`
+	Description      types.String `tfsdk:"description"`
+	ID               types.Int64  `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	PipelineUUID     types.String `tfsdk:"pipeline_uuid"`
+	Project          types.String `tfsdk:"project"`
+	ScheduleInterval types.String `tfsdk:"schedule_interval"`
+	ScheduleType     types.String `tfsdk:"schedule_type"`
+	Settings         types.Object `tfsdk:"settings"`
+	Sla              types.Int64  `tfsdk:"sla"`
+	StartTime        types.String `tfsdk:"start_time"`
+	Status           types.String `tfsdk:"status"`
+	Token            types.String `tfsdk:"token"`
+	UpdatedAt        types.String `tfsdk:"updated_at"`
+	Variables        types.Map    `tfsdk:"variables"`
+}
+
+type PipelineScheduleSettingsModel struct {
+	AllowBlocksToFail        types.Bool   `tfsdk:"allow_blocks_to_fail"`
+	CreateInitialPipelineRun types.Bool   `tfsdk:"create_initial_pipeline_run"`
+	SkipIfPreviousRunning    types.Bool   `tfsdk:"skip_if_previous_running"`
+	Timeout                  types.Int64  `tfsdk:"timeout"`
+	TimeoutStatus            types.String `tfsdk:"timeout_status"`
+}
+
+func (s PipelineScheduleSettingsModel) GetAttrType() map[string]attr.Type {
+	return map[string]attr.Type{
+		"allow_blocks_to_fail":        types.BoolType,
+		"create_initial_pipeline_run": types.BoolType,
+		"skip_if_previous_running":    types.BoolType,
+		"timeout":                     types.Int64Type,
+		"timeout_status":              types.StringType,
+	}
+}
+
+func getPipelineScheduleModel(ctx context.Context, pipelineSchedule mageai.PipelineSchedule) (*PipelineScheduleModel, error) {
+	settingsValue := PipelineScheduleSettingsModel{
+		AllowBlocksToFail:        types.BoolValue(pipelineSchedule.Settings.AllowBlocksToFail),
+		CreateInitialPipelineRun: types.BoolValue(pipelineSchedule.Settings.CreateInitialPipelineRun),
+		SkipIfPreviousRunning:    types.BoolValue(pipelineSchedule.Settings.SkipIfPreviousRunning),
+		Timeout:                  types.Int64PointerValue(pipelineSchedule.Settings.Timeout),
+		TimeoutStatus:            types.StringPointerValue(pipelineSchedule.Settings.TimeoutStatus),
+	}
+
+	settingsObjectValue, diags := types.ObjectValueFrom(ctx, settingsValue.GetAttrType(), settingsValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("error getting pipeline schedule settings")
+	}
+
+	variables, err := convertVariablesToStringMap(pipelineSchedule.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("error getting variables: %w", err)
+	}
+
+	variablesMapValue, diags := types.MapValueFrom(ctx, types.StringType, variables)
+	if diags.HasError() {
+		return nil, fmt.Errorf("error getting variables")
+	}
+
+	pipelineScheduleState := PipelineScheduleModel{
+		CreatedAt:        types.StringValue(pipelineSchedule.CreatedAt),
+		Description:      types.StringValue(pipelineSchedule.Description),
+		ID:               types.Int64Value(pipelineSchedule.ID),
+		Name:             types.StringValue(pipelineSchedule.Name),
+		PipelineUUID:     types.StringValue(pipelineSchedule.PipelineUUID),
+		ScheduleInterval: types.StringValue(pipelineSchedule.ScheduleInterval),
+		ScheduleType:     types.StringValue(pipelineSchedule.ScheduleType),
+		Settings:         settingsObjectValue,
+		Sla:              types.Int64Null(),
+		StartTime:        types.StringNull(),
+		Status:           types.StringValue(pipelineSchedule.Status),
+		Token:            types.StringValue(pipelineSchedule.Token),
+		UpdatedAt:        types.StringValue(pipelineSchedule.UpdatedAt),
+		Variables:        variablesMapValue,
+	}
+
+	// Mage AI returns null for the SLA and the start time that are not set
+	if pipelineSchedule.Sla != 0 {
+		pipelineScheduleState.Sla = types.Int64Value(pipelineSchedule.Sla)
+	}
+	if pipelineSchedule.StartTime != "" {
+		pipelineScheduleState.StartTime = types.StringValue(pipelineSchedule.StartTime)
+	}
+	return &pipelineScheduleState, nil
+}
+
+// pipelineScheduleStartTimeLayouts are the layouts of the start times Mage AI
+// accepts. Start times without a time zone are in UTC.
+var pipelineScheduleStartTimeLayouts = []string{
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+}
+
+// parsePipelineScheduleStartTime returns the instant of the start time, and
+// whether it could be parsed.
+func parsePipelineScheduleStartTime(startTime string) (time.Time, bool) {
+	for _, layout := range pipelineScheduleStartTimeLayouts {
+		if t, err := time.Parse(layout, startTime); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// setPipelineScheduleStartTime keeps the prior start time when it is the same
+// instant as the start time returned by Mage AI, which normalizes it, e.g.
+// returns 2024-01-01 00:00:00 for 2024-01-01T00:00:00Z.
+func setPipelineScheduleStartTime(model *PipelineScheduleModel, prior types.String) {
+	if prior.IsNull() || prior.IsUnknown() {
+		return
+	}
+
+	priorTime, ok := parsePipelineScheduleStartTime(prior.ValueString())
+	if !ok {
+		return
+	}
+
+	startTime, ok := parsePipelineScheduleStartTime(model.StartTime.ValueString())
+	if ok && startTime.Equal(priorTime) {
+		model.StartTime = prior
+	}
+}
+
+// convertVariablesToStringMap flattens the variables returned by Mage AI into
+// strings. Non-string values are kept in their JSON representation.
+func convertVariablesToStringMap(variables map[string]any) (map[string]string, error) {
+	variablesMap := map[string]string{}
+	for key, value := range variables {
+		if s, ok := value.(string); ok {
+			variablesMap[key] = s
+			continue
+		}
+
+		encodedValue, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		variablesMap[key] = string(encodedValue)
+	}
+	return variablesMap, nil
+}
+
+func convertVariablesMapToAnyMap(ctx context.Context, variables basetypes.MapValue) (map[string]any, error) {
+	if variables.IsNull() || variables.IsUnknown() {
+		return map[string]any{}, nil
+	}
+
+	variablesMap := map[string]string{}
+	diags := variables.ElementsAs(ctx, &variablesMap, false)
+	if diags.HasError() {
+		return nil, fmt.Errorf("could not get variables, unexpected error: %v", diags.Errors())
+	}
+
+	variablesAnyMap := map[string]any{}
+	for key, value := range variablesMap {
+		variablesAnyMap[key] = value
+	}
+	return variablesAnyMap, nil
+}
+
+func convertPipelineScheduleSettingsObjectToModel(ctx context.Context, settingsObject basetypes.ObjectValue) (*mageai.PipelineScheduleSettings, error) {
+	if settingsObject.IsNull() || settingsObject.IsUnknown() {
+		return &mageai.PipelineScheduleSettings{}, nil
+	}
+
+	settingsModel := PipelineScheduleSettingsModel{}
+	diags := settingsObject.As(ctx, &settingsModel, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})
+	if diags.HasError() {
+		return nil, fmt.Errorf("could not get settings, unexpected error: %v", diags.Errors())
+	}
+
+	settings := &mageai.PipelineScheduleSettings{
+		AllowBlocksToFail:        settingsModel.AllowBlocksToFail.ValueBool(),
+		CreateInitialPipelineRun: settingsModel.CreateInitialPipelineRun.ValueBool(),
+		SkipIfPreviousRunning:    settingsModel.SkipIfPreviousRunning.ValueBool(),
+		Timeout:                  settingsModel.Timeout.ValueInt64Pointer(),
+		TimeoutStatus:            settingsModel.TimeoutStatus.ValueStringPointer(),
+	}
+	return settings, nil
+}
+
+func makePipelineScheduleRequestFromModel(ctx context.Context, p PipelineScheduleModel) (*mageai.PipelineScheduleRequest, error) {
+	settings, err := convertPipelineScheduleSettingsObjectToModel(ctx, p.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("error converting pipeline schedule settings: %v", err)
+	}
+
+	variables, err := convertVariablesMapToAnyMap(ctx, p.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("error converting pipeline schedule variables: %v", err)
+	}
+
+	return &mageai.PipelineScheduleRequest{
+		Description:      p.Description.ValueString(),
+		Name:             p.Name.ValueString(),
+		ScheduleInterval: p.ScheduleInterval.ValueString(),
+		ScheduleType:     mageai.ScheduleType(p.ScheduleType.ValueString()),
+		Settings:         *settings,
+		Sla:              p.Sla.ValueInt64Pointer(),
+		StartTime:        p.StartTime.ValueStringPointer(),
+		Status:           mageai.ScheduleStatus(p.Status.ValueString()),
+		Variables:        variables,
+	}, nil
+}
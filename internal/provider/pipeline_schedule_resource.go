@@ -0,0 +1,391 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &PipelineScheduleResource{}
+	_ resource.ResourceWithConfigure   = &PipelineScheduleResource{}
+	_ resource.ResourceWithImportState = &PipelineScheduleResource{}
+)
+
+// NewPipelineScheduleResource is a helper function to simplify the provider implementation.
+func NewPipelineScheduleResource() resource.Resource {
+	return &PipelineScheduleResource{}
+}
+
+// PipelineScheduleResource defines the resource implementation.
+type PipelineScheduleResource struct {
+	client mageai.Client
+}
+
+// Metadata returns the resource type name.
+func (r *PipelineScheduleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_schedule"
+}
+
+// Schema defines the schema for the resource.
+func (r *PipelineScheduleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		Description: "Create a trigger (pipeline schedule) for a pipeline.",
+		Attributes: map[string]schema.Attribute{
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The created_at.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Computed:    true,
+				Optional:    true,
+				Description: "The description of the trigger.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Unique identifier for the trigger.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Human readable name of the trigger.",
+			},
+			"pipeline_uuid": schema.StringAttribute{
+				Required:    true,
+				Description: "The UUID of the pipeline to create the trigger for.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": projectResourceAttribute(),
+			"schedule_interval": schema.StringAttribute{
+				Computed:    true,
+				Optional:    true,
+				Description: "The frequency of a `time` trigger: `@once`, `@hourly`, `@daily`, `@weekly`, `@monthly`, `@always_on` or a cron expression such as `*/5 * * * *`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"schedule_type": schema.StringAttribute{
+				Computed:    true,
+				Optional:    true,
+				Description: "Type of the trigger: `api`, `event`, `time`. The event matchers of an `event` trigger, i.e. the events that run the pipeline, cannot be managed with this resource and must be configured in Mage AI.",
+				Default:     stringdefault.StaticString("time"),
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"api", "event", "time"}...),
+				},
+			},
+			"settings": schema.SingleNestedAttribute{
+				Computed:    true,
+				Optional:    true,
+				Description: "Run settings of the trigger.",
+				Attributes: map[string]schema.Attribute{
+					"allow_blocks_to_fail": schema.BoolAttribute{
+						Computed:    true,
+						Optional:    true,
+						Description: "Keep running the pipeline even if blocks fail.",
+					},
+					"create_initial_pipeline_run": schema.BoolAttribute{
+						Computed:    true,
+						Optional:    true,
+						Description: "Create an initial pipeline run if the start date is before the current execution period.",
+					},
+					"skip_if_previous_running": schema.BoolAttribute{
+						Computed:    true,
+						Optional:    true,
+						Description: "Skip the run if the previous pipeline run is still in progress.",
+					},
+					"timeout": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Timeout (in seconds) for a pipeline run.",
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"timeout_status": schema.StringAttribute{
+						Optional:    true,
+						Description: "Status of the pipeline run after it times out: `cancelled`, `failed`.",
+						Validators: []validator.String{
+							stringvalidator.OneOf([]string{"cancelled", "failed"}...),
+						},
+					},
+				},
+			},
+			"sla": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The SLA (in seconds) of a pipeline run. A run that exceeds it is reported as missing the SLA.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"start_time": schema.StringAttribute{
+				Optional:    true,
+				Description: "The start date and time of a `time` trigger, e.g. `2024-01-01 00:00:00`.",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Optional:    true,
+				Description: "Status of the trigger: `active`, `inactive`.",
+				Default:     stringdefault.StaticString("inactive"),
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"active", "inactive"}...),
+				},
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The token used to call an `api` trigger.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The updated_at value.",
+			},
+			"variables": schema.MapAttribute{
+				Computed:    true,
+				Optional:    true,
+				Description: "Runtime variables passed to the pipeline runs of the trigger.",
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *PipelineScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan PipelineScheduleModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	pipelineScheduleRequest, err := makePipelineScheduleRequestFromModel(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating pipeline schedule",
+			err.Error(),
+		)
+		return
+	}
+
+	client := projectClient(r.client, plan.Project)
+	plan.Project = projectValue(client)
+	createPipelineScheduleResponse, err := client.PipelineScheduleAPI().CreatePipelineSchedule(ctx, plan.PipelineUUID.ValueStringPointer(), &mageai.CreatePipelineScheduleRequest{PipelineSchedule: *pipelineScheduleRequest})
+	if err != nil {
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error creating pipeline schedule",
+			"Could not create pipeline schedule, unexpected error: ",
+			err,
+		))
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	pipelineScheduleModel, err := getPipelineScheduleModel(ctx, createPipelineScheduleResponse.PipelineSchedule)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting pipeline schedule model",
+			err.Error(),
+		)
+		return
+	}
+	pipelineScheduleModel.Project = plan.Project
+	setPipelineScheduleStartTime(pipelineScheduleModel, plan.StartTime)
+	plan = *pipelineScheduleModel
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *PipelineScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state PipelineScheduleModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed pipeline schedule value from Mage AI
+	client := projectClient(r.client, state.Project)
+	readPipelineScheduleResponse, err := client.PipelineScheduleAPI().ReadPipelineSchedule(ctx, state.ID.ValueInt64Pointer())
+	if err != nil {
+		// Remove the resource from state so that Terraform plans to recreate it
+		if mageai.IsNotFound(err) {
+			tflog.Warn(ctx, "The pipeline schedule no longer exists, removing it from state", map[string]any{"id": state.ID.ValueInt64()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error getting pipeline schedule",
+			"",
+			err,
+		))
+		return
+	}
+
+	// Overwrite items with refreshed state
+	pipelineScheduleModel, err := getPipelineScheduleModel(ctx, readPipelineScheduleResponse.PipelineSchedule)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting pipeline schedule model",
+			err.Error(),
+		)
+		return
+	}
+	pipelineScheduleModel.Project = state.Project
+	setPipelineScheduleStartTime(pipelineScheduleModel, state.StartTime)
+	state = *pipelineScheduleModel
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *PipelineScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan PipelineScheduleModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	pipelineScheduleRequest, err := makePipelineScheduleRequestFromModel(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating pipeline schedule",
+			err.Error(),
+		)
+		return
+	}
+
+	// Update existing pipeline schedule
+	client := projectClient(r.client, plan.Project)
+	updatePipelineScheduleResponse, err := client.PipelineScheduleAPI().UpdatePipelineSchedule(ctx, plan.ID.ValueInt64Pointer(), &mageai.UpdatePipelineScheduleRequest{PipelineSchedule: *pipelineScheduleRequest})
+	if err != nil {
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error updating pipeline schedule",
+			"Could not update pipeline schedule, unexpected error: ",
+			err,
+		))
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	pipelineScheduleModel, err := getPipelineScheduleModel(ctx, updatePipelineScheduleResponse.PipelineSchedule)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting pipeline schedule model",
+			err.Error(),
+		)
+		return
+	}
+	pipelineScheduleModel.Project = plan.Project
+	setPipelineScheduleStartTime(pipelineScheduleModel, plan.StartTime)
+	plan = *pipelineScheduleModel
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *PipelineScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state PipelineScheduleModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete existing pipeline schedule
+	client := projectClient(r.client, state.Project)
+	err := client.PipelineScheduleAPI().DeletePipelineSchedule(ctx, state.ID.ValueInt64Pointer())
+	if err != nil {
+		// The pipeline schedule is already gone, there is nothing left to delete
+		if mageai.IsNotFound(err) {
+			return
+		}
+
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error deleting pipeline schedule",
+			"Could not delete pipeline schedule, unexpected error: ",
+			err,
+		))
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *PipelineScheduleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected mageai.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = pd.client
+}
+
+func (r *PipelineScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected the numeric ID of the pipeline schedule, got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	importProject(ctx, r.client, resp)
+}
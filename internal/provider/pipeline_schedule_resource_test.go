@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func TestAccPipelineScheduleResource(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccPipelineScheduleResourceConfig("inactive", "@daily"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_pipeline_schedule.test", "id", "1"),
+					resource.TestCheckResourceAttr("mageai_pipeline_schedule.test", "name", "example_trigger"),
+					resource.TestCheckResourceAttr("mageai_pipeline_schedule.test", "pipeline_uuid", "example_pipeline"),
+					resource.TestCheckResourceAttr("mageai_pipeline_schedule.test", "schedule_type", "time"),
+					resource.TestCheckResourceAttr("mageai_pipeline_schedule.test", "schedule_interval", "@daily"),
+					resource.TestCheckResourceAttr("mageai_pipeline_schedule.test", "status", "inactive"),
+					resource.TestCheckResourceAttr("mageai_pipeline_schedule.test", "settings.skip_if_previous_running", "true"),
+					resource.TestCheckResourceAttr("mageai_pipeline_schedule.test", "variables.env", "dev"),
+					resource.TestCheckResourceAttrSet("mageai_pipeline_schedule.test", "token"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(server) + testAccPipelineScheduleResourceConfig("active", "@hourly"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_pipeline_schedule.test", "id", "1"),
+					resource.TestCheckResourceAttr("mageai_pipeline_schedule.test", "schedule_interval", "@hourly"),
+					resource.TestCheckResourceAttr("mageai_pipeline_schedule.test", "status", "active"),
+				),
+			},
+			{
+				ResourceName:      "mageai_pipeline_schedule.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccPipelineScheduleImportStateID,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "mageai_pipeline_schedule.test",
+				ImportState:   true,
+				ImportStateId: "example_trigger",
+				ExpectError:   regexp.MustCompile(`Expected the numeric ID of the pipeline schedule`),
+			},
+			{
+				PreConfig: func() {
+					server.DeletePipelineSchedule(1)
+				},
+				Config: testAccProviderConfig(server) + testAccPipelineScheduleResourceConfig("active", "@hourly"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_pipeline_schedule.test", "id", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPipelineScheduleResourceStartTime(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccPipelineScheduleResourceStartTimeConfig("2024-01-01T01:00:00+01:00"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_pipeline_schedule.test", "start_time", "2024-01-01T01:00:00+01:00"),
+					testAccCheckPipelineScheduleStartTime(server, "2024-01-01 00:00:00"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(server) + testAccPipelineScheduleResourceStartTimeConfig("2024-01-02T00:00:00Z"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_pipeline_schedule.test", "start_time", "2024-01-02T00:00:00Z"),
+					testAccCheckPipelineScheduleStartTime(server, "2024-01-02 00:00:00"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPipelineScheduleResourceClearSettings(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccPipelineScheduleResourceClearSettingsConfig(`
+  sla        = 3600
+  start_time = "2024-01-01 00:00:00"
+
+  settings = {
+    timeout        = 600
+    timeout_status = "failed"
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_pipeline_schedule.test", "sla", "3600"),
+					resource.TestCheckResourceAttr("mageai_pipeline_schedule.test", "start_time", "2024-01-01 00:00:00"),
+					resource.TestCheckResourceAttr("mageai_pipeline_schedule.test", "settings.timeout", "600"),
+					resource.TestCheckResourceAttr("mageai_pipeline_schedule.test", "settings.timeout_status", "failed"),
+				),
+			},
+			// Removing the attributes clears them in Mage AI
+			{
+				Config: testAccProviderConfig(server) + testAccPipelineScheduleResourceClearSettingsConfig(`
+  settings = {
+    skip_if_previous_running = true
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("mageai_pipeline_schedule.test", "sla"),
+					resource.TestCheckNoResourceAttr("mageai_pipeline_schedule.test", "start_time"),
+					resource.TestCheckNoResourceAttr("mageai_pipeline_schedule.test", "settings.timeout"),
+					resource.TestCheckNoResourceAttr("mageai_pipeline_schedule.test", "settings.timeout_status"),
+					func(s *terraform.State) error {
+						pipelineSchedule, ok := server.PipelineSchedule(1)
+						if !ok {
+							return fmt.Errorf("pipeline schedule 1 does not exist")
+						}
+
+						settings := pipelineSchedule.Settings
+						if pipelineSchedule.Sla != 0 || pipelineSchedule.StartTime != "" || settings.Timeout != nil || settings.TimeoutStatus != nil {
+							return fmt.Errorf("expected the SLA, start time and timeout to be cleared, got %+v", pipelineSchedule)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccPipelineScheduleResourceClearSettingsConfig(attributes string) string {
+	return fmt.Sprintf(`
+resource "mageai_pipeline" "test" {
+  name = "example_pipeline"
+}
+
+resource "mageai_pipeline_schedule" "test" {
+  name              = "example_trigger"
+  pipeline_uuid     = mageai_pipeline.test.uuid
+  schedule_interval = "@daily"
+%s}
+`, attributes)
+}
+
+func testAccPipelineScheduleResourceStartTimeConfig(startTime string) string {
+	return fmt.Sprintf(`
+resource "mageai_pipeline" "test" {
+  name = "example_pipeline"
+}
+
+resource "mageai_pipeline_schedule" "test" {
+  name              = "example_trigger"
+  pipeline_uuid     = mageai_pipeline.test.uuid
+  schedule_interval = "@daily"
+  start_time        = %q
+}
+`, startTime)
+}
+
+// testAccCheckPipelineScheduleStartTime checks the start time Mage AI stored
+// for the pipeline schedule.
+func testAccCheckPipelineScheduleStartTime(server *mageaitest.Server, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		pipelineSchedule, ok := server.PipelineSchedule(1)
+		if !ok {
+			return fmt.Errorf("pipeline schedule 1 does not exist")
+		}
+
+		if pipelineSchedule.StartTime != expected {
+			return fmt.Errorf("expected start time %q, got %q", expected, pipelineSchedule.StartTime)
+		}
+		return nil
+	}
+}
+
+func testAccPipelineScheduleImportStateID(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["mageai_pipeline_schedule.test"]
+	if !ok {
+		return "", fmt.Errorf("resource mageai_pipeline_schedule.test not found")
+	}
+	if _, err := strconv.ParseInt(rs.Primary.Attributes["id"], 10, 64); err != nil {
+		return "", fmt.Errorf("unexpected id %q: %w", rs.Primary.Attributes["id"], err)
+	}
+	return rs.Primary.Attributes["id"], nil
+}
+
+func testAccPipelineScheduleResourceConfig(status, scheduleInterval string) string {
+	return fmt.Sprintf(`
+resource "mageai_pipeline" "test" {
+  name = "example_pipeline"
+}
+
+resource "mageai_pipeline_schedule" "test" {
+  name              = "example_trigger"
+  pipeline_uuid     = mageai_pipeline.test.uuid
+  schedule_interval = %[2]q
+  start_time        = "2024-01-01 00:00:00"
+  status            = %[1]q
+
+  settings = {
+    skip_if_previous_running = true
+  }
+
+  variables = {
+    env = "dev"
+  }
+}
+`, status, scheduleInterval)
+}
+
+func TestPipelineScheduleResourceReadRemovesMissingPipelineSchedule(t *testing.T) {
+	r := &PipelineScheduleResource{client: newNotFoundTestClient(t)}
+	testResourceReadRemovesMissing(t, r, map[string]any{
+		"id":            int64(1),
+		"name":          "example_trigger",
+		"pipeline_uuid": "example_pipeline",
+	})
+}
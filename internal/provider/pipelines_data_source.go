@@ -3,9 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
 )
@@ -28,7 +31,15 @@ type PipelinesDataSource struct {
 
 // PipelinesDataSourceModel describes the data source data model.
 type PipelinesDataSourceModel struct {
-	Pipelines []PipelineModel `tfsdk:"pipelines"`
+	IncludeBlocks  types.Bool      `tfsdk:"include_blocks"`
+	IncludeContent types.Bool      `tfsdk:"include_content"`
+	NameRegex      types.String    `tfsdk:"name_regex"`
+	Pipelines      []PipelineModel `tfsdk:"pipelines"`
+	Project        types.String    `tfsdk:"project"`
+	Status         types.String    `tfsdk:"status"`
+	Tags           types.Set       `tfsdk:"tags"`
+	TagsMatch      types.String    `tfsdk:"tags_match"`
+	Type           types.String    `tfsdk:"type"`
 }
 
 // Metadata returns the data source type name.
@@ -40,8 +51,23 @@ func (d *PipelinesDataSource) Metadata(ctx context.Context, req datasource.Metad
 func (d *PipelinesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		Description: "To retrieve all pipelines.",
+		Description: "To retrieve all pipelines, or the pipelines matching the filters.",
 		Attributes: map[string]schema.Attribute{
+			"include_blocks": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to return the blocks of the pipelines. Defaults to `true`.",
+			},
+			"include_content": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to return the content of the blocks of the pipelines. Defaults to `true`. Set it to `false` to reduce the size of the response and of the state when there are many pipelines.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the pipelines whose name matches this regular expression ([RE2 syntax](https://github.com/google/re2/wiki/Syntax)).",
+				Validators: []validator.String{
+					isRegex(),
+				},
+			},
 			"pipelines": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -238,6 +264,33 @@ func (d *PipelinesDataSource) Schema(ctx context.Context, req datasource.SchemaR
 					},
 				},
 			},
+			"project": projectDataSourceAttribute(),
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the pipelines with this status, derived from their triggers: `active`, `inactive`, `no_schedules`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"active", "inactive", "no_schedules"}...),
+				},
+			},
+			"tags": schema.SetAttribute{
+				Optional:    true,
+				Description: "Only return the pipelines with these tags. See `tags_match`.",
+				ElementType: types.StringType,
+			},
+			"tags_match": schema.StringAttribute{
+				Optional:    true,
+				Description: "Whether the pipelines must have `any` of the `tags`, or `all` of them. Defaults to `any`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"all", "any"}...),
+				},
+			},
+			"type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the pipelines of this type: `integration`, `pyspark`, `python`, `streaming`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"integration", "pyspark", "python", "streaming"}...),
+				},
+			},
 		},
 	}
 }
@@ -265,17 +318,45 @@ func (d *PipelinesDataSource) Configure(ctx context.Context, req datasource.Conf
 func (d *PipelinesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state PipelinesDataSourceModel
 
-	readDatabasesResponse, err := d.client.PipelineAPI().ReadPipelines(ctx)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	includeBlocks := state.IncludeBlocks.IsNull() || state.IncludeBlocks.ValueBool()
+	filter := mageai.PipelinesFilter{
+		AllTags:     state.TagsMatch.ValueString() == "all",
+		OmitContent: !includeBlocks || !state.IncludeContent.IsNull() && !state.IncludeContent.ValueBool(),
+		Status:      mageai.PipelineStatus(state.Status.ValueString()),
+		Type:        mageai.PipelineType(state.Type.ValueString()),
+	}
+
+	// The regular expression is validated by the schema
+	if !state.NameRegex.IsNull() {
+		filter.NameRegex, _ = regexp.Compile(state.NameRegex.ValueString())
+	}
+
+	if !state.Tags.IsNull() {
+		resp.Diagnostics.Append(state.Tags.ElementsAs(ctx, &filter.Tags, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	client := projectClient(d.client, state.Project)
+	readPipelinesResponse, err := client.PipelineAPI().ReadPipelines(ctx, &filter)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
 			"Error getting pipelines",
-			err.Error(),
-		)
+			"",
+			err,
+		))
 		return
 	}
 
 	// Map response body to model
-	for _, pipeline := range readDatabasesResponse.Pipelines {
+	state.Pipelines = nil
+	for _, pipeline := range readPipelinesResponse.Pipelines {
 		pipelineState, err := getPipelineModel(ctx, pipeline)
 		if err != nil {
 			resp.Diagnostics.AddError(
@@ -284,6 +365,14 @@ func (d *PipelinesDataSource) Read(ctx context.Context, req datasource.ReadReque
 			)
 			return
 		}
+
+		if !includeBlocks {
+			pipelineState.Blocks = nil
+		} else if filter.OmitContent {
+			for i := range pipelineState.Blocks {
+				pipelineState.Blocks[i].Content = types.StringNull()
+			}
+		}
 		state.Pipelines = append(state.Pipelines, *pipelineState)
 	}
 
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func TestAccPipelinesDataSource(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "mageai_pipeline" "batch" {
+  name = "batch_pipeline"
+}
+
+resource "mageai_pipeline" "streaming" {
+  name = "streaming_pipeline"
+  type = "streaming"
+}
+
+data "mageai_pipelines" "test" {
+  depends_on = [mageai_pipeline.batch, mageai_pipeline.streaming]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mageai_pipelines.test", "pipelines.#", "2"),
+					resource.TestCheckResourceAttr("data.mageai_pipelines.test", "pipelines.0.uuid", "batch_pipeline"),
+					resource.TestCheckResourceAttr("data.mageai_pipelines.test", "pipelines.0.type", "python"),
+					resource.TestCheckResourceAttr("data.mageai_pipelines.test", "pipelines.1.uuid", "streaming_pipeline"),
+					resource.TestCheckResourceAttr("data.mageai_pipelines.test", "pipelines.1.type", "streaming"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPipelinesDataSourceFilters(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "mageai_pipelines" "test" {
+  name_regex = "("
+}
+`,
+				ExpectError: regexp.MustCompile(`value\s+must\s+be\s+a\s+valid\s+regular\s+expression`),
+			},
+			{
+				Config: testAccProviderConfig(server) + `
+resource "mageai_pipeline" "sales" {
+  name = "sales_etl"
+  tags = ["daily", "sales"]
+}
+
+resource "mageai_pipeline" "sales_backfill" {
+  name = "sales_backfill"
+  tags = ["sales"]
+}
+
+resource "mageai_pipeline" "events" {
+  name = "events"
+  type = "streaming"
+  tags = ["daily"]
+}
+
+resource "mageai_block" "load" {
+  name          = "load"
+  pipeline_uuid = mageai_pipeline.sales.uuid
+  type          = "data_loader"
+  content       = "print(1)"
+}
+
+resource "mageai_pipeline_schedule" "sales" {
+  name              = "sales_daily"
+  pipeline_uuid     = mageai_pipeline.sales.uuid
+  schedule_type     = "time"
+  schedule_interval = "@daily"
+  start_time        = "2024-09-01 00:00:00"
+  status            = "active"
+}
+
+resource "mageai_pipeline_schedule" "sales_backfill" {
+  name              = "sales_backfill_daily"
+  pipeline_uuid     = mageai_pipeline.sales_backfill.uuid
+  schedule_type     = "time"
+  schedule_interval = "@daily"
+  start_time        = "2024-09-01 00:00:00"
+  status            = "inactive"
+}
+
+locals {
+  depends_on = [mageai_block.load, mageai_pipeline.events, mageai_pipeline_schedule.sales, mageai_pipeline_schedule.sales_backfill]
+}
+
+data "mageai_pipelines" "streaming" {
+  type       = "streaming"
+  depends_on = [local.depends_on]
+}
+
+data "mageai_pipelines" "any_tags" {
+  tags       = ["daily", "sales"]
+  depends_on = [local.depends_on]
+}
+
+data "mageai_pipelines" "all_tags" {
+  tags       = ["daily", "sales"]
+  tags_match = "all"
+  depends_on = [local.depends_on]
+}
+
+data "mageai_pipelines" "name_regex" {
+  name_regex = "^sales_"
+  depends_on = [local.depends_on]
+}
+
+data "mageai_pipelines" "inactive" {
+  status     = "inactive"
+  depends_on = [local.depends_on]
+}
+
+data "mageai_pipelines" "no_schedules" {
+  status     = "no_schedules"
+  depends_on = [local.depends_on]
+}
+
+data "mageai_pipelines" "without_content" {
+  name_regex      = "^sales_etl$"
+  include_content = false
+  depends_on      = [local.depends_on]
+}
+
+data "mageai_pipelines" "without_blocks" {
+  name_regex     = "^sales_etl$"
+  include_blocks = false
+  depends_on     = [local.depends_on]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mageai_pipelines.streaming", "pipelines.#", "1"),
+					resource.TestCheckResourceAttr("data.mageai_pipelines.streaming", "pipelines.0.uuid", "events"),
+					resource.TestCheckResourceAttr("data.mageai_pipelines.any_tags", "pipelines.#", "3"),
+					resource.TestCheckResourceAttr("data.mageai_pipelines.all_tags", "pipelines.#", "1"),
+					resource.TestCheckResourceAttr("data.mageai_pipelines.all_tags", "pipelines.0.uuid", "sales_etl"),
+					resource.TestCheckResourceAttr("data.mageai_pipelines.name_regex", "pipelines.#", "2"),
+					resource.TestCheckResourceAttr("data.mageai_pipelines.inactive", "pipelines.#", "1"),
+					resource.TestCheckResourceAttr("data.mageai_pipelines.inactive", "pipelines.0.uuid", "sales_backfill"),
+					resource.TestCheckResourceAttr("data.mageai_pipelines.no_schedules", "pipelines.#", "1"),
+					resource.TestCheckResourceAttr("data.mageai_pipelines.no_schedules", "pipelines.0.uuid", "events"),
+					resource.TestCheckResourceAttr("data.mageai_pipelines.without_content", "pipelines.0.blocks.#", "1"),
+					resource.TestCheckResourceAttr("data.mageai_pipelines.without_content", "pipelines.0.blocks.0.uuid", "load"),
+					resource.TestCheckNoResourceAttr("data.mageai_pipelines.without_content", "pipelines.0.blocks.0.content"),
+					resource.TestCheckNoResourceAttr("data.mageai_pipelines.without_blocks", "pipelines.0.blocks.#"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// useStateOrEmptyList returns a plan modifier that plans an empty list when
+// the resource is created and keeps the prior state value otherwise. It is
+// used for computed lists that are only changed by other resources.
+func useStateOrEmptyList() planmodifier.List {
+	return useStateOrEmptyListModifier{}
+}
+
+type useStateOrEmptyListModifier struct{}
+
+func (m useStateOrEmptyListModifier) Description(ctx context.Context) string {
+	return "Once set, the value of this attribute in state will not change. It is an empty list on creation."
+}
+
+func (m useStateOrEmptyListModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateOrEmptyListModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	// Do nothing if there is a known planned value or the resource is being destroyed
+	if !req.PlanValue.IsUnknown() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// Do nothing if there is an unknown configuration value
+	if req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		resp.PlanValue = types.ListValueMust(req.PlanValue.ElementType(ctx), []attr.Value{})
+		return
+	}
+
+	if !req.StateValue.IsNull() {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// useNullWhenConfigured returns a plan modifier that plans a null value when
+// the other attribute is configured. It is used for computed attributes that
+// are replaced by the other attribute, such as content by content_file.
+func useNullWhenConfigured(other path.Path) planmodifier.String {
+	return useNullWhenConfiguredModifier{other: other}
+}
+
+type useNullWhenConfiguredModifier struct {
+	other path.Path
+}
+
+func (m useNullWhenConfiguredModifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("The value of this attribute is null when %s is configured.", m.other)
+}
+
+func (m useNullWhenConfiguredModifier) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("The value of this attribute is null when `%s` is configured.", m.other)
+}
+
+func (m useNullWhenConfiguredModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Do nothing if the attribute is configured or the resource is being destroyed
+	if !req.ConfigValue.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var other types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, m.other, &other)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !other.IsNull() && !other.IsUnknown() {
+		resp.PlanValue = types.StringNull()
+	}
+}
+
+// useBlockContentSHA256 returns a plan modifier that plans the hash of the
+// normalized content of a block, read from the content or content_file
+// attribute, so that only changes of the code produce a difference. The
+// prior state value is kept when the content is not configured.
+func useBlockContentSHA256() planmodifier.String {
+	return blockContentSHA256Modifier{}
+}
+
+type blockContentSHA256Modifier struct{}
+
+func (m blockContentSHA256Modifier) Description(ctx context.Context) string {
+	return "The value of this attribute is the SHA-256 hash of the normalized content or content_file."
+}
+
+func (m blockContentSHA256Modifier) MarkdownDescription(ctx context.Context) string {
+	return "The value of this attribute is the SHA-256 hash of the normalized `content` or `content_file`."
+}
+
+func (m blockContentSHA256Modifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Do nothing if the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var content, contentFile types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content"), &content)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content_file"), &contentFile)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case content.IsUnknown() || contentFile.IsUnknown():
+		resp.PlanValue = types.StringUnknown()
+	case !contentFile.IsNull():
+		fileContent, err := os.ReadFile(contentFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("content_file"),
+				"Unable to Read Block Content File",
+				fmt.Sprintf("Could not read the content of the block from %q: %s", contentFile.ValueString(), err),
+			)
+			return
+		}
+		resp.PlanValue = types.StringValue(blockContentSHA256(string(fileContent)))
+	case !content.IsNull():
+		resp.PlanValue = types.StringValue(blockContentSHA256(content.ValueString()))
+	case !req.StateValue.IsNull():
+		// The content is set by Mage AI, e.g. from the template of the block
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// requiresReplaceIfBlockContentChanged returns a plan modifier that replaces
+// the block when its normalized content changes. Changes of the whitespace Mage
+// AI does not preserve update the block in place, since Terraform does not
+// allow planning the prior value instead of the configured one.
+func requiresReplaceIfBlockContentChanged() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = req.StateValue.IsNull() || req.PlanValue.IsUnknown() || req.PlanValue.IsNull() ||
+				normalizeBlockContent(req.PlanValue.ValueString()) != normalizeBlockContent(req.StateValue.ValueString())
+		},
+		"If the value of this attribute changes other than by whitespace, Terraform will destroy and recreate the resource.",
+		"If the value of this attribute changes other than by whitespace, Terraform will destroy and recreate the resource.",
+	)
+}
+
+// useBlockSlug returns a plan modifier that plans the UUID Mage AI derives
+// from the name attribute, so that it is known before the block is created.
+func useBlockSlug() planmodifier.String {
+	return blockSlugModifier{}
+}
+
+type blockSlugModifier struct{}
+
+func (m blockSlugModifier) Description(ctx context.Context) string {
+	return "The value of this attribute is the UUID derived from the name."
+}
+
+func (m blockSlugModifier) MarkdownDescription(ctx context.Context) string {
+	return "The value of this attribute is the UUID derived from the `name`."
+}
+
+func (m blockSlugModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Do nothing if the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var name types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if name.IsUnknown() {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+	resp.PlanValue = types.StringValue(mageai.CleanName(name.ValueString()))
+}
+
+// useStateProject returns a plan modifier that keeps the prior state value,
+// even when it is null, when the project is not configured. A resource keeps
+// living in the project it was created in when the project of the provider
+// changes.
+func useStateProject() planmodifier.String {
+	return stateProjectModifier{}
+}
+
+type stateProjectModifier struct{}
+
+func (m stateProjectModifier) Description(ctx context.Context) string {
+	return "Once set, the value of this attribute in state will not change unless it is configured."
+}
+
+func (m stateProjectModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m stateProjectModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Do nothing if the resource is created or destroyed, or the project is
+	// configured
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || !req.ConfigValue.IsNull() {
+		return
+	}
+	resp.PlanValue = req.StateValue
+}
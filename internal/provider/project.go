@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// projectResourceAttribute returns the project attribute of the resources,
+// which records the project they live in so that they are read from and
+// deleted in the same project when the provider project changes.
+func projectResourceAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The project the resource lives in on a Mage AI server hosting several projects. Defaults to the `project` of the provider, and changing it replaces the resource. Existing resources stay in their project when the `project` of the provider changes.",
+		PlanModifiers: []planmodifier.String{
+			useStateProject(),
+			stringplanmodifier.RequiresReplace(),
+		},
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+}
+
+// projectDataSourceAttribute returns the project attribute of the data
+// sources.
+func projectDataSourceAttribute() datasourceschema.StringAttribute {
+	return datasourceschema.StringAttribute{
+		Optional:    true,
+		Description: "The project to read from on a Mage AI server hosting several projects. Defaults to the `project` of the provider.",
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+}
+
+// importProject records the project of the provider in the state of an
+// imported resource, which is read from that project.
+func importProject(ctx context.Context, client mageai.Client, resp *resource.ImportStateResponse) {
+	// Do nothing if the provider is not configured
+	if client == nil {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project"), projectValue(client))...)
+}
+
+// projectClient returns the client sending the requests to the project, the
+// client of the provider when the project is not set.
+func projectClient(client mageai.Client, project types.String) mageai.Client {
+	if project.IsNull() || project.IsUnknown() {
+		return client
+	}
+	return client.WithProject(project.ValueString())
+}
+
+// projectValue returns the project the client sends the requests to, null
+// when they go to the active project.
+func projectValue(client mageai.Client) types.String {
+	if client.Project() == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(client.Project())
+}
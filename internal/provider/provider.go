@@ -3,12 +3,16 @@ package provider
 import (
 	"context"
 	"os"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
@@ -17,6 +21,12 @@ import (
 // Ensure the implementation satisfies the expected interfaces.
 var _ provider.Provider = &MageAIProvider{}
 
+const (
+	defaultMaxRetries     int64 = 3
+	defaultRequestTimeout int64 = 10
+	defaultRetryMaxWait   int64 = 30
+)
+
 // MageAIProvider defines the provider implementation.
 type MageAIProvider struct {
 	// version is set to the provider version on release, "dev" when the
@@ -27,12 +37,25 @@ type MageAIProvider struct {
 
 // MageAIProviderModel maps provider schema data to a Go type.
 type MageAIProviderModel struct {
-	ApiKey types.String `tfsdk:"api_key"`
-	Host   types.String `tfsdk:"host"`
+	ApiKey             types.String `tfsdk:"api_key"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	Host               types.String `tfsdk:"host"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	MaxRetries         types.Int64  `tfsdk:"max_retries"`
+	Password           types.String `tfsdk:"password"`
+	Project            types.String `tfsdk:"project"`
+	RequestTimeout     types.Int64  `tfsdk:"request_timeout"`
+	RetryMaxWait       types.Int64  `tfsdk:"retry_max_wait"`
+	ServerVersionCheck types.String `tfsdk:"server_version_check"`
+	Username           types.String `tfsdk:"username"`
 }
 
 type providerData struct {
-	client mageai.Client
+	client             mageai.Client
+	serverVersionCheck string
 }
 
 // Metadata returns the provider type name.
@@ -52,11 +75,85 @@ func (p *MageAIProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "The path to a file with PEM encoded CA certificates to trust, in addition to the system ones, when verifying the certificate of the Mage AI server. Conflicts with `ca_cert_pem`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_pem")),
+				},
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM encoded CA certificates to trust, in addition to the system ones, when verifying the certificate of the Mage AI server. Conflicts with `ca_cert_file`.",
+				Optional:    true,
+			},
+			"client_cert": schema.StringAttribute{
+				Description: "The PEM encoded certificate to present when the Mage AI server requires client certificates. Must be set together with `client_key`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				Description: "The PEM encoded private key of `client_cert`. Must be set together with `client_cert`.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert")),
+				},
+			},
 			"host": schema.StringAttribute{
 				Description: "The host of the Mage AI server",
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Whether to skip the verification of the certificate of the Mage AI server. Only meant for testing, defaults to `false`.",
+				Optional:    true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "The number of times a failed request is retried. Only requests that can safely be sent again are retried, e.g. on connection errors or `502`, `503` and `504` responses. Defaults to `3`, set to `0` to disable retries.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"password": schema.StringAttribute{
+				Description: "The password of the user to authenticate calls with when user authentication is enabled on the Mage AI server. Must be set together with `username`.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"project": schema.StringAttribute{
+				Description: "The project to manage on a Mage AI server hosting several projects, as listed in `projects` of its `settings.yaml`. The project is activated before sending requests, and resources can override it with their own `project` attribute. Requests go to the active project when it is not set.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"request_timeout": schema.Int64Attribute{
+				Description: "The maximum time (in seconds) a single request to the Mage AI server can take. Defaults to `10`.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				Description: "The maximum time (in seconds) to wait between two retries. Defaults to `30`.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"server_version_check": schema.StringAttribute{
+				Description: "What to do at plan time when a configured attribute requires a newer version of Mage AI than the one the server runs, since older servers silently ignore it: `warn`, `error` or `off`. Defaults to `warn`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(serverVersionCheckWarn, serverVersionCheckError, serverVersionCheckOff),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "The username or email of the user to authenticate calls with when user authentication is enabled on the Mage AI server. A session is created on first use and refreshed when it expires. Must be set together with `password`.",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -91,6 +188,33 @@ func (p *MageAIProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		)
 	}
 
+	if config.Username.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("username"),
+			"Unknown Mage AI Username",
+			"The provider cannot create the Mage AI client as there is an unknown configuration value for the Username. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MAGEAI_USERNAME environment variable.",
+		)
+	}
+
+	if config.Password.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Unknown Mage AI Password",
+			"The provider cannot create the Mage AI client as there is an unknown configuration value for the Password. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MAGEAI_PASSWORD environment variable.",
+		)
+	}
+
+	if config.Project.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("project"),
+			"Unknown Mage AI Project",
+			"The provider cannot create the Mage AI client as there is an unknown configuration value for the Project. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MAGEAI_PROJECT environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -99,6 +223,9 @@ func (p *MageAIProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	// with Terraform configuration value if set.
 	apiKey := os.Getenv("MAGEAI_API_KEY")
 	host := os.Getenv("MAGEAI_HOST")
+	username := os.Getenv("MAGEAI_USERNAME")
+	password := os.Getenv("MAGEAI_PASSWORD")
+	project := os.Getenv("MAGEAI_PROJECT")
 
 	if !config.ApiKey.IsNull() {
 		apiKey = config.ApiKey.ValueString()
@@ -108,6 +235,38 @@ func (p *MageAIProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		host = config.Host.ValueString()
 	}
 
+	if !config.Username.IsNull() {
+		username = config.Username.ValueString()
+	}
+
+	if !config.Password.IsNull() {
+		password = config.Password.ValueString()
+	}
+
+	if !config.Project.IsNull() {
+		project = config.Project.ValueString()
+	}
+
+	maxRetries := defaultMaxRetries
+	if !config.MaxRetries.IsNull() {
+		maxRetries = config.MaxRetries.ValueInt64()
+	}
+
+	requestTimeout := defaultRequestTimeout
+	if !config.RequestTimeout.IsNull() {
+		requestTimeout = config.RequestTimeout.ValueInt64()
+	}
+
+	retryMaxWait := defaultRetryMaxWait
+	if !config.RetryMaxWait.IsNull() {
+		retryMaxWait = config.RetryMaxWait.ValueInt64()
+	}
+
+	serverVersionCheck := serverVersionCheckWarn
+	if !config.ServerVersionCheck.IsNull() {
+		serverVersionCheck = config.ServerVersionCheck.ValueString()
+	}
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
@@ -131,6 +290,26 @@ func (p *MageAIProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		)
 	}
 
+	if username != "" && password == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Missing Mage AI Password",
+			"The provider cannot create the Mage AI client as there is a missing or empty value for the Password while the Username is set. "+
+				"Set the Password value in the configuration or use the MAGEAI_PASSWORD environment variable. "+
+				"If either is already set, ensure the value is not empty.",
+		)
+	}
+
+	if password != "" && username == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("username"),
+			"Missing Mage AI Username",
+			"The provider cannot create the Mage AI client as there is a missing or empty value for the Username while the Password is set. "+
+				"Set the Username value in the configuration or use the MAGEAI_USERNAME environment variable. "+
+				"If either is already set, ensure the value is not empty.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -138,8 +317,24 @@ func (p *MageAIProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	// If any of the expected configurations are in wrong format, return
 	// errors with provider-specific guidance.
 
+	caCertPEM := []byte(config.CACertPEM.ValueString())
+	if !config.CACertFile.IsNull() {
+		var err error
+		caCertPEM, err = os.ReadFile(config.CACertFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Unable to Read Mage AI CA Certificate",
+				"The provider cannot create the Mage AI client as the CA certificate file could not be read: "+err.Error(),
+			)
+			return
+		}
+	}
+
 	ctx = tflog.SetField(ctx, "MAGEAI_API_KEY", apiKey)
 	ctx = tflog.SetField(ctx, "MAGEAI_HOST", host)
+	ctx = tflog.SetField(ctx, "MAGEAI_USERNAME", username)
+	ctx = tflog.SetField(ctx, "MAGEAI_PROJECT", project)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "MAGEAI_API_KEY")
 
 	tflog.Debug(ctx, "Creating Mage AI client")
@@ -147,8 +342,19 @@ func (p *MageAIProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	// Create a new Mage AI client using the configuration values
 	client, err := mageai.New(
 		&mageai.ClientConfig{
-			Host:   host,
-			ApiKey: apiKey,
+			Host:               host,
+			ApiKey:             apiKey,
+			Project:            project,
+			CACertPEM:          caCertPEM,
+			ClientCertPEM:      []byte(config.ClientCert.ValueString()),
+			ClientKeyPEM:       []byte(config.ClientKey.ValueString()),
+			InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+			RequestTimeout:     time.Duration(requestTimeout) * time.Second,
+			WrapTransport:      mageai.NewLoggingTransport,
+			Username:           username,
+			Password:           password,
+			MaxRetries:         int(maxRetries),
+			RetryWaitMax:       time.Duration(retryMaxWait) * time.Second,
 		},
 	)
 	if err != nil {
@@ -164,7 +370,8 @@ func (p *MageAIProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	// Make the Mage AI client available during DataSource and Resource
 	// type Configure methods.
 	providerData := &providerData{
-		client: client,
+		client:             client,
+		serverVersionCheck: serverVersionCheck,
 	}
 	resp.DataSourceData = *providerData
 	resp.ResourceData = *providerData
@@ -175,7 +382,13 @@ func (p *MageAIProvider) Configure(ctx context.Context, req provider.ConfigureRe
 func (p *MageAIProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewBlockResource,
+		NewIOConfigProfileResource,
+		NewPipelineDAGResource,
 		NewPipelineResource,
+		NewPipelineRunResource,
+		NewPipelineScheduleResource,
+		NewSecretResource,
+		NewVariableResource,
 	}
 }
 
@@ -185,7 +398,11 @@ func (p *MageAIProvider) DataSources(ctx context.Context) []func() datasource.Da
 		NewBlockDataSource,
 		NewBlocksDataSource,
 		NewPipelineDataSource,
+		NewPipelineGraphDataSource,
+		NewPipelineRunsDataSource,
 		NewPipelinesDataSource,
+		NewServerInfoDataSource,
+		NewVariablesDataSource,
 	}
 }
 
@@ -0,0 +1,301 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	frameworkresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+// testAccProtoV6ProviderFactories are used to instantiate a provider during
+// acceptance testing. The factory function will be invoked for every Terraform
+// CLI command executed to create a provider server to which the CLI can
+// reattach.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"mageai": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// testAccProviderConfig returns the provider configuration pointing at the
+// given stand-in Mage AI server.
+func testAccProviderConfig(server *mageaitest.Server) string {
+	return fmt.Sprintf(`
+provider "mageai" {
+  host    = %[1]q
+  api_key = %[2]q
+}
+`, server.URL, mageaitest.APIKey)
+}
+
+// mageaiNotFoundResponse is the error envelope Mage AI returns for a record
+// that does not exist.
+const mageaiNotFoundResponse = `{"error": {"code": 404, "errors": ["Record not found."], "message": "Record not found.", "type": "record_not_found"}}`
+
+// newNotFoundTestClient returns a client for a stand-in Mage AI server that
+// answers every request with the not found error envelope.
+func newNotFoundTestClient(t *testing.T) mageai.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(mageaiNotFoundResponse))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := mageai.New(&mageai.ClientConfig{Host: server.URL, ApiKey: "test"})
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	t.Cleanup(client.Close)
+	return client
+}
+
+// newTestResourceState returns a state for the resource schema with the
+// given attributes set and every other attribute null.
+func newTestResourceState(t *testing.T, r frameworkresource.Resource, attributes map[string]any) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &frameworkresource.SchemaResponse{}
+	r.Schema(ctx, frameworkresource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("getting schema: %v", schemaResp.Diagnostics)
+	}
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	for name, value := range attributes {
+		diags := state.SetAttribute(ctx, path.Root(name), value)
+		if diags.HasError() {
+			t.Fatalf("setting %s: %v", name, diags)
+		}
+	}
+	return state
+}
+
+// testResourceReadRemovesMissing reads a resource whose remote object was
+// deleted and checks that it is removed from state without diagnostics.
+func testResourceReadRemovesMissing(t *testing.T, r frameworkresource.Resource, attributes map[string]any) {
+	t.Helper()
+	ctx := context.Background()
+
+	state := newTestResourceState(t, r, attributes)
+	resp := &frameworkresource.ReadResponse{State: state}
+	r.Read(ctx, frameworkresource.ReadRequest{State: state}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("expected the resource to be removed from state")
+	}
+}
+
+func TestAccProviderInvalidAPIKey(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "mageai" {
+  host    = %q
+  api_key = "invalid"
+}
+
+data "mageai_pipelines" "test" {}
+`, server.URL),
+				ExpectError: regexp.MustCompile(`(?i)api key`),
+			},
+		},
+	})
+}
+
+func TestAccProviderSessionAuthentication(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.RequireUserAuthentication("admin@admin.com", "admin", time.Hour)
+
+	config := func(password string) string {
+		return fmt.Sprintf(`
+provider "mageai" {
+  host     = %q
+  api_key  = %q
+  username = "admin@admin.com"
+  password = %q
+}
+
+data "mageai_pipelines" "test" {}
+`, server.URL, mageaitest.APIKey, password)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccProviderConfig(server) + `data "mageai_pipelines" "test" {}`,
+				ExpectError: regexp.MustCompile(`(?i)oauth token`),
+			},
+			{
+				Config:      config("invalid"),
+				ExpectError: regexp.MustCompile(`(?i)password invalid`),
+			},
+			{
+				Config: config("admin"),
+				Check:  resource.TestCheckResourceAttr("data.mageai_pipelines.test", "pipelines.#", "0"),
+			},
+		},
+	})
+}
+
+func TestAccProviderProject(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.AddProjects("sales", "marketing")
+
+	config := func(project string) string {
+		return fmt.Sprintf(`
+provider "mageai" {
+  host    = %q
+  api_key = %q
+  project = %q
+}
+
+resource "mageai_pipeline" "orders" {
+  name = "orders"
+}
+
+resource "mageai_pipeline" "campaigns" {
+  name    = "campaigns"
+  project = "marketing"
+}
+
+data "mageai_pipeline" "campaigns" {
+  uuid    = mageai_pipeline.campaigns.uuid
+  project = "marketing"
+}
+`, server.URL, mageaitest.APIKey, project)
+	}
+
+	// projectPipelinesExist checks that the pipelines are in the projects
+	// they were created in, or have been deleted from them.
+	projectPipelinesExist := func(exist bool) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			for project, uuid := range map[string]string{"sales": "orders", "marketing": "campaigns"} {
+				if _, ok := server.ProjectPipeline(project, uuid); ok != exist {
+					return fmt.Errorf("expected pipeline %s in project %s to exist: %t", uuid, project, exist)
+				}
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             projectPipelinesExist(false),
+		Steps: []resource.TestStep{
+			{
+				Config: config("sales"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_pipeline.orders", "project", "sales"),
+					resource.TestCheckResourceAttr("mageai_pipeline.campaigns", "project", "marketing"),
+					resource.TestCheckResourceAttr("data.mageai_pipeline.campaigns", "name", "campaigns"),
+					projectPipelinesExist(true),
+				),
+			},
+			{
+				ResourceName:                         "mageai_pipeline.orders",
+				ImportState:                          true,
+				ImportStateId:                        "orders",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "uuid",
+			},
+			{
+				// The resources stay in their project when the project of the
+				// provider changes
+				Config: config("marketing"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: projectPipelinesExist(true),
+			},
+		},
+	})
+}
+
+func TestAccProviderTLS(t *testing.T) {
+	clientCAs, clientCert, clientKey := mageaitest.NewClientCertificate(t)
+	server := mageaitest.NewTLSServer(t, clientCAs)
+
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caCertFile, server.CertificatePEM(), 0o600); err != nil {
+		t.Fatalf("writing CA certificate: %v", err)
+	}
+
+	config := func(tlsConfig string) string {
+		return fmt.Sprintf(`
+provider "mageai" {
+  host    = %q
+  api_key = %q
+%s
+}
+
+data "mageai_pipelines" "test" {}
+`, server.URL, mageaitest.APIKey, tlsConfig)
+	}
+
+	clientCertConfig := fmt.Sprintf(`
+  client_cert = %q
+  client_key  = %q
+`, clientCert, clientKey)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(clientCertConfig),
+				ExpectError: regexp.MustCompile(`certificate\s+signed\s+by\s+unknown\s+authority`),
+			},
+			{
+				Config:      config(fmt.Sprintf("ca_cert_pem = %q", server.CertificatePEM())),
+				ExpectError: regexp.MustCompile(`certificate\s+required`),
+			},
+			{
+				Config:      config(fmt.Sprintf("ca_cert_pem = %q\nclient_cert = %q", server.CertificatePEM(), clientCert)),
+				ExpectError: regexp.MustCompile(`Invalid\s+Attribute\s+Combination`),
+			},
+			{
+				Config: config(fmt.Sprintf("ca_cert_pem = %q", server.CertificatePEM()) + clientCertConfig),
+				Check:  resource.TestCheckResourceAttr("data.mageai_pipelines.test", "pipelines.#", "0"),
+			},
+			{
+				Config: config(fmt.Sprintf("ca_cert_file = %q", caCertFile) + clientCertConfig),
+				Check:  resource.TestCheckResourceAttr("data.mageai_pipelines.test", "pipelines.#", "0"),
+			},
+			{
+				Config: config("insecure_skip_verify = true" + clientCertConfig),
+				Check:  resource.TestCheckResourceAttr("data.mageai_pipelines.test", "pipelines.#", "0"),
+			},
+		},
+	})
+}
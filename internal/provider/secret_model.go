@@ -0,0 +1,11 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type SecretResourceModel struct {
+	Name    types.String `tfsdk:"name"`
+	Project types.String `tfsdk:"project"`
+	Value   types.String `tfsdk:"value"`
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &SecretResource{}
+	_ resource.ResourceWithConfigure   = &SecretResource{}
+	_ resource.ResourceWithImportState = &SecretResource{}
+)
+
+// NewSecretResource is a helper function to simplify the provider implementation.
+func NewSecretResource() resource.Resource {
+	return &SecretResource{}
+}
+
+// SecretResource defines the resource implementation.
+type SecretResource struct {
+	client mageai.Client
+}
+
+// Metadata returns the resource type name.
+func (r *SecretResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret"
+}
+
+// Schema defines the schema for the resource.
+func (r *SecretResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		Description: "Create a secret in the Mage AI secrets store. Mage AI never returns the value of a secret, so only the existence of the secret is refreshed and changes made to its value outside of Terraform are not detected.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the secret.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"project": projectResourceAttribute(),
+			"value": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: "The value of the secret. Changing the value replaces the secret.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceIfPriorValueKnown,
+						"Changing the value replaces the secret, unless the resource was imported.",
+						"Changing the value replaces the secret, unless the resource was imported.",
+					),
+				},
+			},
+		},
+	}
+}
+
+// requiresReplaceIfPriorValueKnown replaces the secret when its value changes.
+// An imported secret has no value in state, which is set without replacing it.
+func requiresReplaceIfPriorValueKnown(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !req.StateValue.IsNull()
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *SecretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan SecretResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	createSecretRequest := &mageai.CreateSecretRequest{
+		Secret: mageai.SecretRequest{
+			Name:  plan.Name.ValueString(),
+			Value: plan.Value.ValueString(),
+		},
+	}
+
+	client := projectClient(r.client, plan.Project)
+	plan.Project = projectValue(client)
+	_, err := client.SecretAPI().CreateSecret(ctx, createSecretRequest)
+	if err != nil {
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error creating secret",
+			"Could not create secret, unexpected error: ",
+			err,
+		))
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *SecretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state SecretResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check that the secret still exists in Mage AI, the value is never returned
+	client := projectClient(r.client, state.Project)
+	_, err := client.SecretAPI().ReadSecret(ctx, state.Name.ValueStringPointer())
+	if err != nil {
+		// Remove the resource from state so that Terraform plans to recreate it
+		if mageai.IsNotFound(err) {
+			tflog.Warn(ctx, "The secret no longer exists, removing it from state", map[string]any{"name": state.Name.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error getting secret",
+			"",
+			err,
+		))
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+// Only the value of an imported secret is updated in place, and it is only
+// stored in state as Mage AI cannot update secrets.
+func (r *SecretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan SecretResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *SecretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state SecretResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete existing secret
+	client := projectClient(r.client, state.Project)
+	err := client.SecretAPI().DeleteSecret(ctx, state.Name.ValueStringPointer())
+	if err != nil {
+		// The secret is already gone, there is nothing left to delete
+		if mageai.IsNotFound(err) {
+			return
+		}
+
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error deleting secret",
+			"Could not delete secret, unexpected error: ",
+			err,
+		))
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *SecretResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected mageai.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = pd.client
+}
+
+func (r *SecretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	importProject(ctx, r.client, resp)
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func TestAccSecretResource(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccSecretResourceConfig("s3cr3t"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_secret.test", "name", "postgres_password"),
+					testAccCheckSecretValue(server, "postgres_password", "s3cr3t"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(server) + testAccSecretResourceConfig("n3w-s3cr3t"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mageai_secret.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckSecretValue(server, "postgres_password", "n3w-s3cr3t"),
+				),
+			},
+			{
+				ResourceName:                         "mageai_secret.test",
+				ImportState:                          true,
+				ImportStateId:                        "postgres_password",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportStateVerifyIgnore:              []string{"value"},
+			},
+			{
+				ResourceName:    "mageai_secret.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   "postgres_password",
+				// The value is never returned, so it is set in state without replacing the secret
+				ExpectNonEmptyPlan: true,
+				ImportPlanChecks: resource.ImportPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mageai_secret.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			{
+				PreConfig: func() {
+					server.DeleteSecret("postgres_password")
+				},
+				Config: testAccProviderConfig(server) + testAccSecretResourceConfig("n3w-s3cr3t"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mageai_secret.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckSecretValue(server, "postgres_password", "n3w-s3cr3t"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSecretValue(server *mageaitest.Server, name, expectedValue string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		value, ok := server.SecretValue(name)
+		if !ok {
+			return fmt.Errorf("secret %s does not exist", name)
+		}
+
+		if value != expectedValue {
+			return fmt.Errorf("expected secret %s to have value %q, got %q", name, expectedValue, value)
+		}
+		return nil
+	}
+}
+
+func testAccSecretResourceConfig(value string) string {
+	return fmt.Sprintf(`
+resource "mageai_secret" "test" {
+  name  = "postgres_password"
+  value = %q
+}
+`, value)
+}
+
+func TestSecretResourceReadRemovesMissingSecret(t *testing.T) {
+	r := &SecretResource{client: newNotFoundTestClient(t)}
+	testResourceReadRemovesMissing(t, r, map[string]any{
+		"name":  "postgres_password",
+		"value": "s3cr3t",
+	})
+}
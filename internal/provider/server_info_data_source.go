@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ServerInfoDataSource{}
+	_ datasource.DataSourceWithConfigure = &ServerInfoDataSource{}
+)
+
+// NewServerInfoDataSource is a helper function to simplify the provider implementation.
+func NewServerInfoDataSource() datasource.DataSource {
+	return &ServerInfoDataSource{}
+}
+
+// ServerInfoDataSource is the data source implementation.
+type ServerInfoDataSource struct {
+	client mageai.Client
+}
+
+type ServerInfoDataSourceModel struct {
+	LatestVersion types.String `tfsdk:"latest_version"`
+	Project       types.String `tfsdk:"project"`
+	ProjectName   types.String `tfsdk:"project_name"`
+	ProjectType   types.String `tfsdk:"project_type"`
+	ProjectUUID   types.String `tfsdk:"project_uuid"`
+	Version       types.String `tfsdk:"version"`
+}
+
+// Metadata returns the data source type name.
+func (d *ServerInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_info"
+}
+
+// Schema defines the schema for the data source.
+func (d *ServerInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		Description: "To get the version of the Mage AI server and the project the provider manages, e.g. to check that the server supports the configured features.",
+		Attributes: map[string]schema.Attribute{
+			"latest_version": schema.StringAttribute{
+				Computed:    true,
+				Description: "The latest released version of Mage AI, as known to the server.",
+			},
+			"project": projectDataSourceAttribute(),
+			"project_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the project.",
+			},
+			"project_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "The type of the project: `standalone`, or `main` and `sub` for the projects of a server hosting several projects.",
+			},
+			"project_uuid": schema.StringAttribute{
+				Computed:    true,
+				Description: "The UUID of the project.",
+			},
+			"version": schema.StringAttribute{
+				Computed:    true,
+				Description: "The version of Mage AI the server runs, e.g. `0.9.76`.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ServerInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected mageai.client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = pd.client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ServerInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ServerInfoDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := projectClient(d.client, state.Project)
+	serverInfo, err := client.ServerInfo(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error getting server info",
+			"",
+			err,
+		))
+		return
+	}
+
+	// Map response body to model
+	state.LatestVersion = types.StringValue(serverInfo.LatestVersion)
+	state.ProjectName = types.StringValue(serverInfo.Name)
+	state.ProjectType = types.StringValue(serverInfo.ProjectType)
+	state.ProjectUUID = types.StringValue(serverInfo.ProjectUUID)
+	state.Version = types.StringValue(serverInfo.Version)
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func TestAccServerInfoDataSource(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.AddProjects("sales")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "mageai_server_info" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mageai_server_info.test", "version", mageaitest.Version),
+					resource.TestCheckResourceAttr("data.mageai_server_info.test", "latest_version", mageaitest.Version),
+					resource.TestCheckResourceAttr("data.mageai_server_info.test", "project_name", "mageaitest"),
+					resource.TestCheckResourceAttr("data.mageai_server_info.test", "project_type", "main"),
+					resource.TestCheckResourceAttrSet("data.mageai_server_info.test", "project_uuid"),
+					resource.TestCheckNoResourceAttr("data.mageai_server_info.test", "project"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(server) + `
+data "mageai_server_info" "sales" {
+  project = "sales"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mageai_server_info.sales", "project", "sales"),
+					resource.TestCheckResourceAttr("data.mageai_server_info.sales", "project_name", "sales"),
+					resource.TestCheckResourceAttr("data.mageai_server_info.sales", "project_type", "sub"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// The values of the server_version_check attribute of the provider.
+const (
+	serverVersionCheckError = "error"
+	serverVersionCheckOff   = "off"
+	serverVersionCheckWarn  = "warn"
+)
+
+// serverRequirement is a configured attribute that is only supported from a
+// version of Mage AI on. Older servers silently ignore it.
+type serverRequirement struct {
+	path       path.Path
+	feature    string
+	minVersion string
+}
+
+// The first versions of Mage AI known to support the attributes of a
+// pipeline.
+var (
+	cacheBlockOutputInMemoryRequirement = serverRequirement{
+		path:       path.Root("cache_block_output_in_memory"),
+		feature:    "The `cache_block_output_in_memory` attribute",
+		minVersion: "0.9.4",
+	}
+	executorCountRequirement = serverRequirement{
+		path:       path.Root("executor_count"),
+		feature:    "The `executor_count` attribute",
+		minVersion: "0.8.0",
+	}
+	runPipelineInOneProcessRequirement = serverRequirement{
+		path:       path.Root("run_pipeline_in_one_process"),
+		feature:    "The `run_pipeline_in_one_process` attribute",
+		minVersion: "0.9.4",
+	}
+)
+
+// blockTypeMinVersions holds the first versions of Mage AI known to support
+// the block types that were added later than the others.
+var blockTypeMinVersions = map[string]string{
+	"global_data_product": "0.9.0",
+}
+
+// blockTypeRequirement returns the requirement of a block type, and whether
+// the block type has one.
+func blockTypeRequirement(blockType string) (serverRequirement, bool) {
+	minVersion, ok := blockTypeMinVersions[blockType]
+	if !ok {
+		return serverRequirement{}, false
+	}
+
+	return serverRequirement{
+		path:       path.Root("type"),
+		feature:    fmt.Sprintf("The `%s` block type", blockType),
+		minVersion: minVersion,
+	}, true
+}
+
+// checkServerVersion reports the requirements the Mage AI server does not
+// meet, as warnings or errors depending on the mode. The version of the
+// server is only read when a requirement is configured, and the check is
+// skipped when it cannot be read.
+func checkServerVersion(ctx context.Context, client mageai.Client, mode string, requirements []serverRequirement) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if mode == serverVersionCheckOff || len(requirements) == 0 {
+		return diags
+	}
+
+	serverInfo, err := client.ServerInfo(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Could not get the version of the Mage AI server, skipping the server version check", map[string]any{"error": err.Error()})
+		return diags
+	}
+
+	for _, requirement := range requirements {
+		if serverInfo.AtLeast(requirement.minVersion) {
+			continue
+		}
+
+		summary := "Unsupported Mage AI Server Version"
+		detail := fmt.Sprintf(
+			"%s requires Mage AI %s or later, but the server runs version %s, which silently ignores it. "+
+				"Upgrade the Mage AI server, remove it from the configuration, or set `server_version_check` of the provider to `off`.",
+			requirement.feature, requirement.minVersion, serverInfo.Version,
+		)
+		if mode == serverVersionCheckError {
+			diags.AddAttributeError(requirement.path, summary, detail)
+		} else {
+			diags.AddAttributeWarning(requirement.path, summary, detail)
+		}
+	}
+	return diags
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func TestAccServerVersionCheck(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.SetVersion("0.8.0")
+
+	pipelineConfig := `
+resource "mageai_pipeline" "test" {
+  name                        = "example_pipeline"
+  run_pipeline_in_one_process = true
+}
+`
+	blockConfig := `
+resource "mageai_pipeline" "test" {
+  name = "example_pipeline"
+}
+
+resource "mageai_block" "test" {
+  name          = "orders"
+  pipeline_uuid = mageai_pipeline.test.uuid
+  type          = "global_data_product"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccServerVersionCheckProviderConfig(server, "error") + pipelineConfig,
+				ExpectError: regexp.MustCompile("The\\s+`run_pipeline_in_one_process`\\s+attribute\\s+requires\\s+Mage\\s+AI\\s+0.9.4\\s+or\\s+later,\\s+but\\s+the\\s+server\\s+runs\\s+version\\s+0.8.0"),
+			},
+			{
+				Config:      testAccServerVersionCheckProviderConfig(server, "error") + blockConfig,
+				ExpectError: regexp.MustCompile("The\\s+`global_data_product`\\s+block\\s+type\\s+requires\\s+Mage\\s+AI\\s+0.9.0\\s+or\\s+later"),
+			},
+			// Warnings do not prevent the apply
+			{
+				Config: testAccProviderConfig(server) + pipelineConfig,
+				Check:  resource.TestCheckResourceAttr("mageai_pipeline.test", "run_pipeline_in_one_process", "true"),
+			},
+			{
+				Config: testAccServerVersionCheckProviderConfig(server, "off") + blockConfig,
+				Check:  resource.TestCheckResourceAttr("mageai_block.test", "type", "global_data_product"),
+			},
+		},
+	})
+}
+
+func TestAccServerVersionCheckSupported(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServerVersionCheckProviderConfig(server, "error") + `
+resource "mageai_pipeline" "test" {
+  name                         = "example_pipeline"
+  cache_block_output_in_memory = true
+  executor_count               = 2
+  run_pipeline_in_one_process  = true
+}
+`,
+				Check: resource.TestCheckResourceAttr("mageai_pipeline.test", "executor_count", "2"),
+			},
+		},
+	})
+
+	if reads := server.ServerInfoReads(); reads == 0 {
+		t.Error("expected the version of the server to be read")
+	}
+}
+
+func testAccServerVersionCheckProviderConfig(server *mageaitest.Server, check string) string {
+	return fmt.Sprintf(`
+provider "mageai" {
+  host                 = %[1]q
+  api_key              = %[2]q
+  server_version_check = %[3]q
+}
+`, server.URL, mageaitest.APIKey, check)
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = jsonValidator{}
+
+// jsonValidator validates that a string attribute is a JSON value.
+type jsonValidator struct{}
+
+// isJSON returns a validator which ensures that any configured string is a
+// JSON value.
+func isJSON() validator.String {
+	return jsonValidator{}
+}
+
+func (v jsonValidator) Description(ctx context.Context) string {
+	return "value must be valid JSON"
+}
+
+func (v jsonValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := decodeVariableValue(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON Value",
+			fmt.Sprintf("Attribute %s %s, got: %q (%s)", req.Path, v.Description(ctx), req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+var _ validator.String = rfc3339Validator{}
+
+// rfc3339Validator validates that a string attribute is an RFC 3339 timestamp.
+type rfc3339Validator struct{}
+
+// isRFC3339 returns a validator which ensures that any configured string is
+// an RFC 3339 timestamp, e.g. 2024-01-02T15:04:05Z.
+func isRFC3339() validator.String {
+	return rfc3339Validator{}
+}
+
+func (v rfc3339Validator) Description(ctx context.Context) string {
+	return "value must be an RFC 3339 timestamp"
+}
+
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v rfc3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+var _ validator.String = regexValidator{}
+
+// regexValidator validates that a string attribute is a regular expression.
+type regexValidator struct{}
+
+// isRegex returns a validator which ensures that any configured string is a
+// regular expression in the RE2 syntax accepted by Go.
+func isRegex() validator.String {
+	return regexValidator{}
+}
+
+func (v regexValidator) Description(ctx context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v regexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Regular Expression Value",
+			fmt.Sprintf("Attribute %s %s, got: %q (%s)", req.Path, v.Description(ctx), req.ConfigValue.ValueString(), err),
+		)
+	}
+}
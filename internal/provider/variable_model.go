@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+type VariableResourceModel struct {
+	Name         types.String `tfsdk:"name"`
+	PipelineUUID types.String `tfsdk:"pipeline_uuid"`
+	Project      types.String `tfsdk:"project"`
+	Value        types.String `tfsdk:"value"`
+}
+
+type VariablesDataSourceModel struct {
+	PipelineUUID types.String    `tfsdk:"pipeline_uuid"`
+	Project      types.String    `tfsdk:"project"`
+	Variables    []VariableModel `tfsdk:"variables"`
+}
+
+type VariableModel struct {
+	BlockUUID types.String `tfsdk:"block_uuid"`
+	Name      types.String `tfsdk:"name"`
+	Type      types.String `tfsdk:"type"`
+	Value     types.String `tfsdk:"value"`
+}
+
+func getVariableModel(blockUUID string, variable mageai.Variable) (*VariableModel, error) {
+	value, err := encodeVariableValue(variable.Value)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding the value of variable %s: %w", variable.UUID, err)
+	}
+
+	variableState := VariableModel{
+		BlockUUID: types.StringValue(blockUUID),
+		Name:      types.StringValue(variable.UUID),
+		Type:      types.StringValue(variable.Type),
+		Value:     types.StringValue(value),
+	}
+	return &variableState, nil
+}
+
+// getVariableResourceValue returns the JSON-encoded value of the variable,
+// keeping the prior value when it is the same JSON value written differently.
+func getVariableResourceValue(priorValue types.String, variable mageai.Variable) (types.String, error) {
+	value, err := encodeVariableValue(variable.Value)
+	if err != nil {
+		return types.StringNull(), fmt.Errorf("error encoding the value of variable %s: %w", variable.UUID, err)
+	}
+
+	if normalizedValue, err := normalizeJSON(priorValue.ValueString()); err == nil && normalizedValue == value {
+		return priorValue, nil
+	}
+	return types.StringValue(value), nil
+}
+
+func makeVariableRequestFromModel(v VariableResourceModel) (*mageai.VariableRequest, error) {
+	value, err := decodeVariableValue(v.Value.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("error decoding the value of variable %s: %w", v.Name.ValueString(), err)
+	}
+
+	return &mageai.VariableRequest{
+		Name:  v.Name.ValueString(),
+		Value: value,
+	}, nil
+}
+
+func encodeVariableValue(value any) (string, error) {
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encodedValue), nil
+}
+
+// decodeVariableValue decodes a JSON value, keeping numbers as written so
+// that large integers are sent unchanged.
+func decodeVariableValue(value string) (any, error) {
+	decoder := json.NewDecoder(bytes.NewBufferString(value))
+	decoder.UseNumber()
+
+	var decodedValue any
+	if err := decoder.Decode(&decodedValue); err != nil {
+		return nil, err
+	}
+
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after the JSON value")
+	}
+	return decodedValue, nil
+}
+
+// normalizeJSON encodes the JSON value the same way as the values read from
+// Mage AI, whose numbers are decoded as float64.
+func normalizeJSON(value string) (string, error) {
+	var decodedValue any
+	if err := json.Unmarshal([]byte(value), &decodedValue); err != nil {
+		return "", err
+	}
+	return encodeVariableValue(decodedValue)
+}
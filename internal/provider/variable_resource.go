@@ -0,0 +1,301 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &VariableResource{}
+	_ resource.ResourceWithConfigure   = &VariableResource{}
+	_ resource.ResourceWithImportState = &VariableResource{}
+)
+
+// NewVariableResource is a helper function to simplify the provider implementation.
+func NewVariableResource() resource.Resource {
+	return &VariableResource{}
+}
+
+// VariableResource defines the resource implementation.
+type VariableResource struct {
+	client mageai.Client
+}
+
+// Metadata returns the resource type name.
+func (r *VariableResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_variable"
+}
+
+// Schema defines the schema for the resource.
+func (r *VariableResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		Description: "Create a global variable of a pipeline.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the variable. It must be a valid Python identifier.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`), "must be a valid Python identifier"),
+				},
+			},
+			"pipeline_uuid": schema.StringAttribute{
+				Required:    true,
+				Description: "The UUID of the pipeline to create the variable in.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": projectResourceAttribute(),
+			"value": schema.StringAttribute{
+				Required:    true,
+				Description: "The JSON-encoded value of the variable, e.g. `jsonencode(\"dev\")` or `jsonencode({ retries = 3 })`.",
+				Validators: []validator.String{
+					isJSON(),
+				},
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *VariableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan VariableResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	variableRequest, err := makeVariableRequestFromModel(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating variable",
+			err.Error(),
+		)
+		return
+	}
+
+	client := projectClient(r.client, plan.Project)
+	plan.Project = projectValue(client)
+	err = client.VariableAPI().CreateVariable(ctx, plan.PipelineUUID.ValueStringPointer(), &mageai.CreateVariableRequest{Variable: *variableRequest})
+	if err != nil {
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error creating variable",
+			"Could not create variable, unexpected error: ",
+			err,
+		))
+		return
+	}
+
+	// Read back the stored value
+	variable, err := client.VariableAPI().ReadVariable(ctx, plan.PipelineUUID.ValueStringPointer(), plan.Name.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error getting variable",
+			"Could not read the created variable, unexpected error: ",
+			err,
+		))
+		return
+	}
+
+	plan.Value, err = getVariableResourceValue(plan.Value, *variable)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting variable model",
+			err.Error(),
+		)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *VariableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state VariableResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed variable value from Mage AI
+	client := projectClient(r.client, state.Project)
+	variable, err := client.VariableAPI().ReadVariable(ctx, state.PipelineUUID.ValueStringPointer(), state.Name.ValueStringPointer())
+	if err != nil {
+		// Remove the resource from state so that Terraform plans to recreate it
+		if mageai.IsNotFound(err) {
+			tflog.Warn(ctx, "The variable no longer exists, removing it from state", map[string]any{"pipeline_uuid": state.PipelineUUID.ValueString(), "name": state.Name.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error getting variable",
+			"",
+			err,
+		))
+		return
+	}
+
+	// Overwrite items with refreshed state
+	state.Value, err = getVariableResourceValue(state.Value, *variable)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting variable model",
+			err.Error(),
+		)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *VariableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan VariableResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	variableRequest, err := makeVariableRequestFromModel(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating variable",
+			err.Error(),
+		)
+		return
+	}
+
+	// Update existing variable
+	client := projectClient(r.client, plan.Project)
+	err = client.VariableAPI().UpdateVariable(ctx, plan.PipelineUUID.ValueStringPointer(), plan.Name.ValueStringPointer(), &mageai.UpdateVariableRequest{Variable: *variableRequest})
+	if err != nil {
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error updating variable",
+			"Could not update variable, unexpected error: ",
+			err,
+		))
+		return
+	}
+
+	// Read back the stored value
+	variable, err := client.VariableAPI().ReadVariable(ctx, plan.PipelineUUID.ValueStringPointer(), plan.Name.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error getting variable",
+			"Could not read the updated variable, unexpected error: ",
+			err,
+		))
+		return
+	}
+
+	plan.Value, err = getVariableResourceValue(plan.Value, *variable)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting variable model",
+			err.Error(),
+		)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *VariableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state VariableResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete existing variable
+	client := projectClient(r.client, state.Project)
+	err := client.VariableAPI().DeleteVariable(ctx, state.PipelineUUID.ValueStringPointer(), state.Name.ValueStringPointer())
+	if err != nil {
+		// The variable is already gone, there is nothing left to delete
+		if mageai.IsNotFound(err) {
+			return
+		}
+
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error deleting variable",
+			"Could not delete variable, unexpected error: ",
+			err,
+		))
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *VariableResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected mageai.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = pd.client
+}
+
+func (r *VariableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	pipelineUUID, name, ok := strings.Cut(req.ID, "/")
+	if !ok || pipelineUUID == "" || name == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: pipeline_uuid/name. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("pipeline_uuid"), pipelineUUID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	importProject(ctx, r.client, resp)
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func TestAccVariableResource(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccVariableResourceConfig(`jsonencode("dev")`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_variable.test", "pipeline_uuid", "example_pipeline"),
+					resource.TestCheckResourceAttr("mageai_variable.test", "name", "env"),
+					resource.TestCheckResourceAttr("mageai_variable.test", "value", `"dev"`),
+				),
+			},
+			{
+				Config: testAccProviderConfig(server) + testAccVariableResourceConfig(`jsonencode({ retries = 3, regions = ["eu", "us"] })`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mageai_variable.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_variable.test", "value", `{"regions":["eu","us"],"retries":3}`),
+				),
+			},
+			{
+				// The same JSON value written differently is kept as written
+				Config: testAccProviderConfig(server) + testAccVariableResourceConfig(`"{ \"retries\": 3, \"regions\": [\"eu\", \"us\"] }"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_variable.test", "value", `{ "retries": 3, "regions": ["eu", "us"] }`),
+				),
+			},
+			{
+				ResourceName:                         "mageai_variable.test",
+				ImportState:                          true,
+				ImportStateId:                        "example_pipeline/env",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportStateVerifyIgnore:              []string{"value"},
+			},
+			{
+				ResourceName:  "mageai_variable.test",
+				ImportState:   true,
+				ImportStateId: "env",
+				ExpectError:   regexp.MustCompile(`Expected import identifier with format: pipeline_uuid/name`),
+			},
+			{
+				PreConfig: func() {
+					server.DeleteVariable("example_pipeline", "env")
+				},
+				Config: testAccProviderConfig(server) + testAccVariableResourceConfig(`jsonencode("dev")`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mageai_variable.test", "value", `"dev"`),
+				),
+			},
+		},
+	})
+}
+
+func TestAccVariableResourceInvalidValue(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccProviderConfig(server) + testAccVariableResourceConfig(`"dev"`),
+				ExpectError: regexp.MustCompile(`value must be valid JSON`),
+			},
+		},
+	})
+}
+
+func testAccVariableResourceConfig(value string) string {
+	return fmt.Sprintf(`
+resource "mageai_pipeline" "test" {
+  name = "example_pipeline"
+}
+
+resource "mageai_variable" "test" {
+  pipeline_uuid = mageai_pipeline.test.uuid
+  name          = "env"
+  value         = %s
+}
+`, value)
+}
+
+func TestVariableResourceReadRemovesMissingVariable(t *testing.T) {
+	r := &VariableResource{client: newNotFoundTestClient(t)}
+	testResourceReadRemovesMissing(t, r, map[string]any{
+		"name":          "env",
+		"pipeline_uuid": "example_pipeline",
+		"value":         `"dev"`,
+	})
+}
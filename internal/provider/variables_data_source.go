@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &VariablesDataSource{}
+	_ datasource.DataSourceWithConfigure = &VariablesDataSource{}
+)
+
+// NewVariablesDataSource is a helper function to simplify the provider implementation.
+func NewVariablesDataSource() datasource.DataSource {
+	return &VariablesDataSource{}
+}
+
+// VariablesDataSource is the data source implementation.
+type VariablesDataSource struct {
+	client mageai.Client
+}
+
+// Metadata returns the data source type name.
+func (d *VariablesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_variables"
+}
+
+// Schema defines the schema for the data source.
+func (d *VariablesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		Description: "Fetch and return the global variables and the block output variables of a pipeline.",
+		Attributes: map[string]schema.Attribute{
+			"pipeline_uuid": schema.StringAttribute{
+				Required:    true,
+				Description: "The UUID of the pipeline to fetch the variables from.",
+			},
+			"project": projectDataSourceAttribute(),
+			"variables": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The variables of the pipeline.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"block_uuid": schema.StringAttribute{
+							Computed:    true,
+							Description: "The UUID of the block that outputs the variable, or `global` for a global variable.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the variable.",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The type of the variable, e.g. `global` or the type of a block output such as `dataframe`.",
+						},
+						"value": schema.StringAttribute{
+							Computed:    true,
+							Description: "The JSON-encoded value of the variable.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *VariablesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected mageai.client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = pd.client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *VariablesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state VariablesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := projectClient(d.client, state.Project)
+	readVariablesResponse, err := client.VariableAPI().ReadVariables(ctx, state.PipelineUUID.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.Append(newClientErrorDiagnostic(
+			"Error getting variables",
+			"",
+			err,
+		))
+		return
+	}
+
+	// Map response body to model
+	variables := make([]VariableModel, 0)
+	for _, blockVariables := range readVariablesResponse.Variables {
+		for _, variable := range blockVariables.Variables {
+			variableState, err := getVariableModel(blockVariables.Block.UUID, variable)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error getting variables",
+					err.Error(),
+				)
+				return
+			}
+			variables = append(variables, *variableState)
+		}
+	}
+	state.Variables = variables
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func TestAccVariablesDataSource(t *testing.T) {
+	server := mageaitest.NewServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccVariableResourceConfig(`jsonencode({ retries = 3 })`),
+			},
+			{
+				PreConfig: func() {
+					server.SetBlockVariable("example_pipeline", "load_data", mageai.Variable{Type: "dataframe", UUID: "output_0", Value: []any{map[string]any{"id": 1}}})
+				},
+				Config: testAccProviderConfig(server) + testAccVariableResourceConfig(`jsonencode({ retries = 3 })`) + `
+data "mageai_variables" "test" {
+  pipeline_uuid = mageai_variable.test.pipeline_uuid
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mageai_variables.test", "variables.#", "2"),
+					resource.TestCheckResourceAttr("data.mageai_variables.test", "variables.0.block_uuid", "global"),
+					resource.TestCheckResourceAttr("data.mageai_variables.test", "variables.0.name", "env"),
+					resource.TestCheckResourceAttr("data.mageai_variables.test", "variables.0.type", "global"),
+					resource.TestCheckResourceAttr("data.mageai_variables.test", "variables.0.value", `{"retries":3}`),
+					resource.TestCheckResourceAttr("data.mageai_variables.test", "variables.1.block_uuid", "load_data"),
+					resource.TestCheckResourceAttr("data.mageai_variables.test", "variables.1.name", "output_0"),
+					resource.TestCheckResourceAttr("data.mageai_variables.test", "variables.1.type", "dataframe"),
+					resource.TestCheckResourceAttr("data.mageai_variables.test", "variables.1.value", `[{"id":1}]`),
+				),
+			},
+		},
+	})
+}
@@ -1,12 +1,12 @@
 package mageai
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 const (
@@ -28,6 +28,39 @@ const (
 
 type BlockType string
 
+// punctuation is string.punctuation of Python, the ASCII punctuation
+// characters.
+const punctuation = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+// CleanName returns the UUID Mage AI derives from the name of a block or a
+// pipeline, as clean_name of mage_ai/shared/utils.py does: the byte order mark,
+// zero width and non-breaking spaces are removed, every ASCII punctuation
+// character and space is replaced with an underscore, names starting with a
+// digit are prefixed with letter_, and the name is lowercased. Unlike a slug,
+// the name is not trimmed and runs of replaced characters are not collapsed.
+func CleanName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\ufeff' || r == '\u200b' || r == '\u00a0':
+			return -1
+		case r == ' ' || strings.ContainsRune(punctuation, r):
+			return '_'
+		}
+		return r
+	}, name)
+
+	if first, _ := utf8.DecodeRuneInString(name); unicode.IsDigit(first) {
+		name = "letter_" + name
+	}
+	return strings.ToLower(name)
+}
+
+var blockResource = restResource[Block]{
+	name:   "block",
+	plural: "blocks",
+	id:     func(block *Block) string { return block.UUID },
+}
+
 type blockResponse struct {
 	Block Block `json:"block"`
 }
@@ -78,100 +111,32 @@ func (c *client) CreateBlock(ctx context.Context, pipelineUUID *string, blockReq
 		return nil, fmt.Errorf("invalid block type: %s", blockRequest.Block.Type)
 	}
 
-	reqBody, err := json.Marshal(blockRequest)
-	if err != nil {
-		return nil, err
-	}
-
-	respBody, err := c.makeAPICall(http.MethodPost, path.Join(PipelinesAPIPath, *pipelineUUID, BlocksAPIPath), bytes.NewBuffer(reqBody))
+	block, err := blockResource.create(ctx, c, path.Join(PipelinesAPIPath, *pipelineUUID, BlocksAPIPath), blockRequest.Block)
 	if err != nil {
 		return nil, err
 	}
-
-	createBlockResponse := blockResponse{}
-	err = json.Unmarshal(respBody, &createBlockResponse)
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
-	}
-
-	if createBlockResponse.Block.UUID == "" {
-		errRes := errorResponse{}
-		err = json.Unmarshal(respBody, &errRes)
-		if err != nil {
-			return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
-		}
-		return nil, fmt.Errorf("error creating block for the pipeline: %s, Status code: %d", errRes.Error.Exception, errRes.Error.Code)
-	}
-	return &createBlockResponse, nil
+	return &blockResponse{Block: *block}, nil
 }
 
 func (c *client) DeleteBlock(ctx context.Context, pipelineUUID *string, blockUUID *string) error {
-	respBody, err := c.makeAPICall(http.MethodDelete, path.Join(PipelinesAPIPath, *pipelineUUID, BlockAPIPath, *blockUUID), nil)
-	if err != nil {
-		return err
-	}
-
-	deleteBlockResponse := blockResponse{}
-	err = json.Unmarshal(respBody, &deleteBlockResponse)
-	if err != nil {
-		return fmt.Errorf("error unmarshalling JSON: %w", err)
-	}
-
-	if deleteBlockResponse.Block.UUID == "" {
-		errRes := errorResponse{}
-		err = json.Unmarshal(respBody, &errRes)
-		if err != nil {
-			return fmt.Errorf("error unmarshalling JSON: %w", err)
-		}
-		return fmt.Errorf("error deleting block: %s, Status code: %d", errRes.Error.Exception, errRes.Error.Code)
-	}
-	return nil
+	_, err := blockResource.delete(ctx, c, path.Join(PipelinesAPIPath, *pipelineUUID, BlockAPIPath, *blockUUID))
+	return err
 }
 
 func (c *client) ReadBlock(ctx context.Context, pipelineUUID *string, blockUUID *string) (*blockResponse, error) {
-	readBlockResponse := blockResponse{}
-	body, err := c.makeAPICall(http.MethodGet, path.Join(PipelinesAPIPath, *pipelineUUID, BlockAPIPath, *blockUUID), nil)
+	block, err := blockResource.read(ctx, c, path.Join(PipelinesAPIPath, *pipelineUUID, BlockAPIPath, *blockUUID), nil)
 	if err != nil {
 		return nil, err
 	}
-
-	err = json.Unmarshal(body, &readBlockResponse)
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
-	}
-
-	if readBlockResponse.Block.UUID == "" {
-		errRes := errorResponse{}
-		err = json.Unmarshal(body, &errRes)
-		if err != nil {
-			return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
-		}
-		return nil, fmt.Errorf("error getting the block for the pipeline: %s, Status code: %d", errRes.Error.Exception, errRes.Error.Code)
-	}
-	return &readBlockResponse, nil
+	return &blockResponse{Block: *block}, nil
 }
 
 func (c *client) ReadBlocks(ctx context.Context, pipelineUUID *string) (*blocksResponse, error) {
-	readBlocksResponse := blocksResponse{}
-	body, err := c.makeAPICall(http.MethodGet, path.Join(PipelinesAPIPath, *pipelineUUID, BlocksAPIPath), nil)
+	blocks, err := blockResource.list(ctx, c, path.Join(PipelinesAPIPath, *pipelineUUID, BlocksAPIPath), nil)
 	if err != nil {
 		return nil, err
 	}
-
-	err = json.Unmarshal(body, &readBlocksResponse)
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
-	}
-
-	if readBlocksResponse.Blocks == nil {
-		errRes := errorResponse{}
-		err = json.Unmarshal(body, &errRes)
-		if err != nil {
-			return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
-		}
-		return nil, fmt.Errorf("error getting blocks for the pipeline: %s, Status code: %d", errRes.Error.Exception, errRes.Error.Code)
-	}
-	return &readBlocksResponse, nil
+	return &blocksResponse{Blocks: blocks}, nil
 }
 
 func (c *client) UpdateBlock(ctx context.Context, pipelineUUID *string, blockUUID *string, blockRequest *UpdateBlockRequest) (*blockResponse, error) {
@@ -179,29 +144,9 @@ func (c *client) UpdateBlock(ctx context.Context, pipelineUUID *string, blockUUI
 		return nil, fmt.Errorf("invalid block type: %s", blockRequest.Block.Type)
 	}
 
-	reqBody, err := json.Marshal(&blockRequest)
-	if err != nil {
-		return nil, err
-	}
-
-	respBody, err := c.makeAPICall(http.MethodPut, path.Join(PipelinesAPIPath, *pipelineUUID, BlocksAPIPath, *blockUUID), bytes.NewBuffer(reqBody))
+	block, err := blockResource.update(ctx, c, path.Join(PipelinesAPIPath, *pipelineUUID, BlocksAPIPath, *blockUUID), blockRequest.Block)
 	if err != nil {
 		return nil, err
 	}
-
-	updateBlockResponse := blockResponse{}
-	err = json.Unmarshal(respBody, &updateBlockResponse)
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
-	}
-
-	if updateBlockResponse.Block.UUID == "" {
-		errRes := errorResponse{}
-		err = json.Unmarshal(respBody, &errRes)
-		if err != nil {
-			return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
-		}
-		return nil, fmt.Errorf("error updating block for the pipeline: %s, Status code: %d", errRes.Error.Exception, errRes.Error.Code)
-	}
-	return &updateBlockResponse, nil
+	return &blockResponse{Block: *block}, nil
 }
@@ -0,0 +1,26 @@
+package mageai
+
+import "testing"
+
+func TestCleanName(t *testing.T) {
+	testCases := map[string]string{
+		"load_data":            "load_data",
+		"Load Data":            "load_data",
+		"  load data  ":        "__load_data__",
+		"load - data (v2).py":  "load___data__v2__py",
+		"Données brutes":       "données_brutes",
+		"export_to_S3__bucket": "export_to_s3__bucket",
+		"1st load":             "letter_1st_load",
+		"\ufeff1st load":       "letter_1st_load",
+		"load\u00a0data\u200b": "loaddata",
+		"tab\tseparated":       "tab\tseparated",
+		"a+b=c|d~e$f^g`h":      "a_b_c_d_e_f_g_h",
+		"":                     "",
+	}
+
+	for name, expected := range testCases {
+		if cleaned := CleanName(name); cleaned != expected {
+			t.Errorf("expected %q to be cleaned to %q, got %q", name, expected, cleaned)
+		}
+	}
+}
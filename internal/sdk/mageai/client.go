@@ -1,6 +1,8 @@
 package mageai
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,18 +14,46 @@ import (
 
 type Client interface {
 	BlockAPI() BlockAPI
+	FileContentAPI() FileContentAPI
+	IOConfigAPI() IOConfigAPI
 	PipelineAPI() PipelineAPI
+	PipelineRunAPI() PipelineRunAPI
+	PipelineScheduleAPI() PipelineScheduleAPI
+	SecretAPI() SecretAPI
+	VariableAPI() VariableAPI
+
+	// ServerInfo returns the version of the Mage AI server and the project the
+	// requests are sent to. It is read once per client.
+	ServerInfo(ctx context.Context) (*ServerInfo, error)
+
+	// Project returns the project of a multi-project Mage AI instance the
+	// requests are sent to, empty when they go to the active project.
+	Project() string
+	// WithProject returns a client sending the requests to the given project,
+	// sharing the connections and the session of the client. An empty project
+	// returns the client itself.
+	WithProject(project string) Client
 	Close()
 }
 
 type client struct {
-	config ClientConfig
-	apiURL *url.URL
+	config       ClientConfig
+	apiURL       *url.URL
+	session      *session
+	projects     *projects
+	serverInfo   *serverInfoCache
+	ioConfigLock *ioConfigLock
 }
 
 func New(config *ClientConfig) (Client, error) {
 	var err error
-	c := &client{config: *config}
+	c := &client{
+		config:       *config,
+		session:      &session{},
+		projects:     newProjects(),
+		serverInfo:   &serverInfoCache{},
+		ioConfigLock: &ioConfigLock{},
+	}
 
 	hostAddress := config.Host
 	if !strings.HasSuffix(config.Host, "/") {
@@ -38,7 +68,22 @@ func New(config *ClientConfig) (Client, error) {
 	c.apiURL.Path = path.Join(c.apiURL.Path, "/api") + "/"
 
 	if c.config.HTTPClient == nil {
-		c.config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+		c.config.HTTPClient, err = newHTTPClient(&c.config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.config.RetryWaitMin <= 0 {
+		c.config.RetryWaitMin = defaultRetryWaitMin
+	}
+
+	if c.config.RetryWaitMax <= 0 {
+		c.config.RetryWaitMax = defaultRetryWaitMax
+	}
+
+	if c.config.RetryWaitMax < c.config.RetryWaitMin {
+		c.config.RetryWaitMin = c.config.RetryWaitMax
 	}
 	return c, nil
 }
@@ -55,28 +100,131 @@ func (c *client) BlockAPI() BlockAPI {
 	return c
 }
 
-func (c *client) makeAPICall(httpMethod, path string, body io.Reader) ([]byte, error) {
-	req, err := http.NewRequest(httpMethod, c.apiURL.String()+path, body)
-	if err != nil {
+func (c *client) FileContentAPI() FileContentAPI {
+	return c
+}
+
+func (c *client) IOConfigAPI() IOConfigAPI {
+	return c
+}
+
+func (c *client) PipelineRunAPI() PipelineRunAPI {
+	return c
+}
+
+func (c *client) PipelineScheduleAPI() PipelineScheduleAPI {
+	return c
+}
+
+func (c *client) SecretAPI() SecretAPI {
+	return c
+}
+
+func (c *client) VariableAPI() VariableAPI {
+	return c
+}
+
+func (c *client) Project() string {
+	return c.config.Project
+}
+
+func (c *client) WithProject(project string) Client {
+	if project == "" || project == c.config.Project {
+		return c
+	}
+
+	projectClient := *c
+	projectClient.config.Project = project
+	return &projectClient
+}
+
+func (c *client) makeAPICall(ctx context.Context, httpMethod, path string, body io.Reader) ([]byte, error) {
+	var reqBody []byte
+	if body != nil {
+		var err error
+		reqBody, err = io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("error reading request body: %w", err)
+		}
+	}
+
+	if err := c.enterProject(ctx, httpMethod, path); err != nil {
 		return nil, err
 	}
+	defer c.leaveProject()
+
+	return c.sendAPICall(ctx, httpMethod, path, reqBody)
+}
+
+// sendAPICall sends a request, retrying it when it fails and it is safe to do
+// so.
+func (c *client) sendAPICall(ctx context.Context, httpMethod, path string, reqBody []byte) ([]byte, error) {
+	sessionRefreshed := false
+	for attempt := 0; ; attempt++ {
+		token, err := c.sessionToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		respBody, resp, err := c.doAPICall(ctx, httpMethod, path, reqBody, token)
+
+		// The session token expired or was revoked, send the request again
+		// once with a new token
+		if c.usesSession() && !sessionRefreshed && IsUnauthorized(err) {
+			c.invalidateSessionToken(token)
+			sessionRefreshed = true
+			attempt--
+			continue
+		}
+
+		if err == nil || attempt >= c.config.MaxRetries || !shouldRetry(httpMethod, err) {
+			return respBody, err
+		}
+
+		timer := time.NewTimer(c.retryWait(attempt, resp))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, wrapContextError(ctx, httpMethod, path, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// doAPICall sends a single request, authenticated with the session token when
+// it is not empty. The returned response, if any, has its body already
+// consumed and is only meant for inspecting the headers.
+func (c *client) doAPICall(ctx context.Context, httpMethod, path string, reqBody []byte, token string) ([]byte, *http.Response, error) {
+	var body io.Reader
+	if reqBody != nil {
+		body = bytes.NewReader(reqBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, httpMethod, c.apiURL.String()+path, body)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-API-KEY", c.config.ApiKey)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("OAUTH-TOKEN", token)
+	}
 	resp, err := c.config.HTTPClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, wrapContextError(ctx, httpMethod, path, err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %w", err)
+		return nil, resp, wrapContextError(ctx, httpMethod, path, fmt.Errorf("error reading response body: %w", err))
+	}
+
+	if apiErr := newAPIError(httpMethod, path, resp.StatusCode, respBody); apiErr != nil {
+		return nil, resp, apiErr
 	}
-	return respBody, nil
+	return respBody, resp, nil
 }
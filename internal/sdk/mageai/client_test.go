@@ -0,0 +1,141 @@
+package mageai_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// newBlockingServer returns a server that holds every request until the
+// client goes away, and a channel that is signaled once a request arrived.
+func newBlockingServer(t *testing.T) (*httptest.Server, <-chan struct{}) {
+	t.Helper()
+
+	received := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+func newTestClient(t *testing.T, host string) mageai.Client {
+	t.Helper()
+
+	client, err := mageai.New(&mageai.ClientConfig{Host: host, ApiKey: "test"})
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	t.Cleanup(client.Close)
+	return client
+}
+
+func TestMakeAPICallCanceled(t *testing.T) {
+	server, received := newBlockingServer(t)
+	client := newTestClient(t, server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-received
+		cancel()
+	}()
+
+	uuid := "example_pipeline"
+	_, err := client.PipelineAPI().ReadPipeline(ctx, &uuid)
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	if !mageai.IsCanceled(err) {
+		t.Errorf("expected IsCanceled to be true for %v", err)
+	}
+
+	if mageai.IsTimeout(err) {
+		t.Errorf("expected IsTimeout to be false for %v", err)
+	}
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error to wrap context.Canceled, got %v", err)
+	}
+
+	var contextErr *mageai.ContextError
+	if !errors.As(err, &contextErr) {
+		t.Fatalf("expected *mageai.ContextError, got %T", err)
+	}
+
+	if contextErr.Method != http.MethodGet || contextErr.Path != "pipelines/example_pipeline" {
+		t.Errorf("unexpected request in error: %s %s", contextErr.Method, contextErr.Path)
+	}
+}
+
+func TestMakeAPICallDeadlineExceeded(t *testing.T) {
+	server, _ := newBlockingServer(t)
+	client := newTestClient(t, server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	pipelineUUID := "example_pipeline"
+	err := client.BlockAPI().DeleteBlock(ctx, &pipelineUUID, &pipelineUUID)
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	if !mageai.IsTimeout(err) {
+		t.Errorf("expected IsTimeout to be true for %v", err)
+	}
+
+	if mageai.IsCanceled(err) {
+		t.Errorf("expected IsCanceled to be false for %v", err)
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected error to wrap context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestMakeAPICallHTTPClientTimeout(t *testing.T) {
+	server, _ := newBlockingServer(t)
+
+	client, err := mageai.New(&mageai.ClientConfig{
+		Host:       server.URL,
+		ApiKey:     "test",
+		HTTPClient: &http.Client{Timeout: 50 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	defer client.Close()
+
+	_, err = client.PipelineAPI().ReadPipelines(context.Background(), nil)
+	if !mageai.IsTimeout(err) {
+		t.Errorf("expected IsTimeout to be true for %v", err)
+	}
+}
+
+func TestMakeAPICallAlreadyCanceled(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.PipelineAPI().ReadPipelines(ctx, nil)
+	if !mageai.IsCanceled(err) {
+		t.Errorf("expected IsCanceled to be true for %v", err)
+	}
+
+	if requests != 0 {
+		t.Errorf("expected no request to reach the server, got %d", requests)
+	}
+}
@@ -1,9 +1,51 @@
 package mageai
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+)
 
 type ClientConfig struct {
-	ApiKey     string
-	Host       string
+	ApiKey string
+	Host   string
+	// Project is the project of a multi-project Mage AI instance the requests
+	// are sent to. It is activated before the requests, which otherwise go to
+	// the active project.
+	Project string
+
+	// HTTPClient is used to send the requests. When it is nil, a client is
+	// created from the TLS settings and RequestTimeout below.
 	HTTPClient *http.Client
+
+	// CACertPEM holds PEM encoded CA certificates trusted, in addition to the
+	// system ones, to verify the certificate of the Mage AI server.
+	CACertPEM []byte
+	// ClientCertPEM and ClientKeyPEM hold the PEM encoded certificate and key
+	// the client presents when the server requires client certificates.
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+	// InsecureSkipVerify disables the verification of the certificate of the
+	// Mage AI server.
+	InsecureSkipVerify bool
+	// WrapTransport, when set, wraps the transport of the created client,
+	// e.g. with NewLoggingTransport.
+	WrapTransport func(http.RoundTripper) http.RoundTripper
+	// RequestTimeout bounds the time of a single request, including reading
+	// the response. It defaults to 10s.
+	RequestTimeout time.Duration
+
+	// Username and Password authenticate the requests with a session token
+	// when Mage AI requires user authentication. The username can also be
+	// the email of the user.
+	Username string
+	Password string
+
+	// MaxRetries is the number of times a failed request is retried, 0
+	// disables retries. Requests are only retried when it is safe to do so,
+	// see shouldRetry.
+	MaxRetries int
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between
+	// retries. They default to 1s and 30s.
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
 }
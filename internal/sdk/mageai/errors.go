@@ -0,0 +1,178 @@
+package mageai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// maxErrorBodyLength limits how much of a non-JSON error response is kept in
+// an APIError.
+const maxErrorBodyLength = 512
+
+// ContextError is returned when a request is aborted because its context was
+// canceled or its deadline was exceeded before Mage AI responded.
+type ContextError struct {
+	Method string
+	Path   string
+	Err    error
+}
+
+func (e *ContextError) Error() string {
+	return fmt.Sprintf("%s %s: %v", e.Method, e.Path, e.Err)
+}
+
+// Unwrap returns context.Canceled or context.DeadlineExceeded.
+func (e *ContextError) Unwrap() error {
+	return e.Err
+}
+
+// IsCanceled reports whether err is caused by a canceled request context.
+func IsCanceled(err error) bool {
+	var contextErr *ContextError
+	return errors.As(err, &contextErr) && errors.Is(contextErr.Err, context.Canceled)
+}
+
+// IsTimeout reports whether err is caused by an exceeded request deadline or
+// HTTP client timeout.
+func IsTimeout(err error) bool {
+	var contextErr *ContextError
+	return errors.As(err, &contextErr) && errors.Is(contextErr.Err, context.DeadlineExceeded)
+}
+
+// wrapContextError converts transport errors caused by the request context or
+// the HTTP client timeout into a ContextError. Other errors are returned as is.
+func wrapContextError(ctx context.Context, httpMethod, path string, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return &ContextError{Method: httpMethod, Path: path, Err: ctxErr}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return &ContextError{Method: httpMethod, Path: path, Err: context.DeadlineExceeded}
+	}
+	return err
+}
+
+// notFoundException matches the exceptions of the 500 errors Mage AI answers
+// with for a missing pipeline or block, e.g. "Pipeline example_pipeline does
+// not exist." or "Block load does not exist in pipeline example_pipeline.".
+var notFoundException = regexp.MustCompile(`^(Pipeline|Block) \S+ does not exist( in pipeline \S+)?\.?$`)
+
+var (
+	// ErrConflict matches an APIError for a resource that already exists.
+	ErrConflict = errors.New("conflict")
+	// ErrNotFound matches an APIError for a resource that does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized matches an APIError for a request with missing or invalid credentials.
+	ErrUnauthorized = errors.New("unauthorized")
+)
+
+// APIError is returned when Mage AI answers with an error, either through a
+// non-2xx HTTP status or through the error envelope of a 200 response.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Code is the error code reported in the Mage AI error envelope.
+	Code int
+	// Exception is the server side exception reported by Mage AI.
+	Exception string
+	// Message is the human readable error message reported by Mage AI.
+	Message string
+	// Type is the error type reported by Mage AI, e.g. `record_not_found`.
+	Type string
+	// Method and Path identify the request that failed.
+	Method string
+	Path   string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s: status code %d", e.Method, e.Path, e.StatusCode)
+	if e.Code != 0 && e.Code != e.StatusCode {
+		msg += fmt.Sprintf(", error code %d", e.Code)
+	}
+
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	if e.Exception != "" && e.Exception != e.Message {
+		msg += " (" + e.Exception + ")"
+	}
+	return msg
+}
+
+// Is reports whether the error matches ErrConflict, ErrNotFound or ErrUnauthorized.
+// Mage AI reports missing pipelines and blocks as a 500 with an exception such
+// as "Pipeline example_pipeline does not exist.", those are matched as
+// ErrNotFound. Other exceptions mentioning a missing object, e.g. a missing
+// column of a query, are not.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrConflict:
+		return e.code() == http.StatusConflict
+	case ErrNotFound:
+		return e.code() == http.StatusNotFound || e.Type == "record_not_found" || notFoundException.MatchString(e.Exception)
+	case ErrUnauthorized:
+		return e.code() == http.StatusUnauthorized || e.code() == http.StatusForbidden
+	}
+	return false
+}
+
+// code returns the Mage AI error code, falling back to the HTTP status code
+// since Mage AI reports most errors with a 200 response.
+func (e *APIError) code() int {
+	if e.Code != 0 {
+		return e.Code
+	}
+	return e.StatusCode
+}
+
+// IsConflict reports whether err is an APIError for a conflicting resource.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsNotFound reports whether err is an APIError for a missing resource.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsUnauthorized reports whether err is an APIError for rejected credentials.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// newAPIError returns an APIError if the response is an error, nil otherwise.
+func newAPIError(httpMethod, path string, statusCode int, body []byte) *APIError {
+	errRes := errorResponse{}
+	_ = json.Unmarshal(body, &errRes)
+
+	if statusCode >= 200 && statusCode < 300 && errRes.Error == nil {
+		return nil
+	}
+
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Method:     httpMethod,
+		Path:       path,
+	}
+
+	if errRes.Error != nil {
+		apiErr.Code = errRes.Error.Code
+		apiErr.Exception = errRes.Error.Exception
+		apiErr.Message = errRes.Error.Message
+		apiErr.Type = errRes.Error.Type
+	} else {
+		apiErr.Message = strings.TrimSpace(string(body))
+		if len(apiErr.Message) > maxErrorBodyLength {
+			apiErr.Message = apiErr.Message[:maxErrorBodyLength] + "..."
+		}
+	}
+	return apiErr
+}
@@ -0,0 +1,144 @@
+package mageai_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+func TestAPIError(t *testing.T) {
+	testCases := map[string]struct {
+		statusCode     int
+		body           string
+		expected       mageai.APIError
+		isConflict     bool
+		isNotFound     bool
+		isUnauthorized bool
+	}{
+		"error envelope with 200 status": {
+			statusCode: http.StatusOK,
+			body:       `{"error": {"code": 404, "exception": "Pipeline example_pipeline does not exist.", "message": "Record not found.", "type": "record_not_found"}}`,
+			expected: mageai.APIError{
+				StatusCode: http.StatusOK,
+				Code:       http.StatusNotFound,
+				Exception:  "Pipeline example_pipeline does not exist.",
+				Message:    "Record not found.",
+				Type:       "record_not_found",
+			},
+			isNotFound: true,
+		},
+		"error envelope with exception": {
+			statusCode: http.StatusOK,
+			body:       `{"error": {"code": 500, "errors": ["Traceback"], "exception": "Pipeline example_pipeline does not exist.", "message": "Pipeline example_pipeline does not exist."}}`,
+			expected: mageai.APIError{
+				StatusCode: http.StatusOK,
+				Code:       http.StatusInternalServerError,
+				Exception:  "Pipeline example_pipeline does not exist.",
+				Message:    "Pipeline example_pipeline does not exist.",
+			},
+			isNotFound: true,
+		},
+		"error envelope with error status": {
+			statusCode: http.StatusUnauthorized,
+			body:       `{"error": {"code": 401, "message": "Invalid API key.", "type": "api_key_invalid"}}`,
+			expected: mageai.APIError{
+				StatusCode: http.StatusUnauthorized,
+				Code:       http.StatusUnauthorized,
+				Message:    "Invalid API key.",
+				Type:       "api_key_invalid",
+			},
+			isUnauthorized: true,
+		},
+		"error status without envelope": {
+			statusCode: http.StatusConflict,
+			body:       "Pipeline already exists\n",
+			expected: mageai.APIError{
+				StatusCode: http.StatusConflict,
+				Message:    "Pipeline already exists",
+			},
+			isConflict: true,
+		},
+		"bad gateway": {
+			statusCode: http.StatusBadGateway,
+			body:       "<html>502 Bad Gateway</html>",
+			expected: mageai.APIError{
+				StatusCode: http.StatusBadGateway,
+				Message:    "<html>502 Bad Gateway</html>",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(testCase.statusCode)
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+			client := newTestClient(t, server.URL)
+
+			uuid := "example_pipeline"
+			_, err := client.PipelineAPI().ReadPipeline(context.Background(), &uuid)
+
+			var apiErr *mageai.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected *mageai.APIError, got %T: %v", err, err)
+			}
+
+			expected := testCase.expected
+			expected.Method = http.MethodGet
+			expected.Path = "pipelines/example_pipeline"
+			if *apiErr != expected {
+				t.Errorf("expected %+v, got %+v", expected, *apiErr)
+			}
+
+			if got := mageai.IsConflict(err); got != testCase.isConflict {
+				t.Errorf("expected IsConflict to be %t", testCase.isConflict)
+			}
+
+			if got := mageai.IsNotFound(err); got != testCase.isNotFound {
+				t.Errorf("expected IsNotFound to be %t", testCase.isNotFound)
+			}
+
+			if got := mageai.IsUnauthorized(err); got != testCase.isUnauthorized {
+				t.Errorf("expected IsUnauthorized to be %t", testCase.isUnauthorized)
+			}
+
+			if testCase.expected.Message != "" && !strings.Contains(err.Error(), testCase.expected.Message) {
+				t.Errorf("expected error message to contain %q, got %q", testCase.expected.Message, err.Error())
+			}
+		})
+	}
+}
+
+func TestAPIErrorIsNotFound(t *testing.T) {
+	testCases := map[string]bool{
+		"Pipeline example_pipeline does not exist.":                            true,
+		"Block load does not exist in pipeline example_pipeline.":              true,
+		"Block transformers/clean does not exist":                              true,
+		"column x does not exist":                                              false,
+		`relation "public.users" does not exist`:                               false,
+		"upstream block y does not exist":                                      false,
+		"profile does not exist in io_config":                                  false,
+		"Pipeline example_pipeline does not exist. Block load does not exist.": false,
+	}
+
+	for exception, expected := range testCases {
+		t.Run(exception, func(t *testing.T) {
+			err := &mageai.APIError{
+				StatusCode: http.StatusOK,
+				Code:       http.StatusInternalServerError,
+				Exception:  exception,
+			}
+
+			if got := mageai.IsNotFound(err); got != expected {
+				t.Errorf("expected IsNotFound to be %t", expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package mageai
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path"
+	"regexp"
+)
+
+const (
+	FileContentsAPIPath = "file_contents"
+)
+
+type FileContentAPI interface {
+	ReadFileContent(ctx context.Context, filePath *string) (*FileContent, error)
+	UpdateFileContent(ctx context.Context, filePath *string, fileContentRequest *UpdateFileContentRequest) (*FileContent, error)
+}
+
+// fileNotFoundException matches the exception Mage AI answers with when the
+// file to read does not exist, e.g. "[Errno 2] No such file or directory:
+// '/home/src/default_repo/io_config.yaml'".
+var fileNotFoundException = regexp.MustCompile(`^\[Errno 2\] No such file or directory`)
+
+var fileContentResource = restResource[FileContent]{
+	name:   "file_content",
+	plural: "file_contents",
+	id:     func(fileContent *FileContent) string { return fileContent.Path },
+}
+
+type UpdateFileContentRequest struct {
+	FileContent FileContentRequest `json:"file_content"`
+}
+
+type FileContentRequest struct {
+	Content string `json:"content"`
+}
+
+// ReadFileContent returns the content of a file of the project, its path
+// being relative to the project.
+func (c *client) ReadFileContent(ctx context.Context, filePath *string) (*FileContent, error) {
+	return fileContentResource.read(ctx, c, fileContentAPIPath(*filePath), nil)
+}
+
+// UpdateFileContent replaces the content of a file of the project, creating
+// the file if it does not exist.
+func (c *client) UpdateFileContent(ctx context.Context, filePath *string, fileContentRequest *UpdateFileContentRequest) (*FileContent, error) {
+	return fileContentResource.update(ctx, c, fileContentAPIPath(*filePath), fileContentRequest.FileContent)
+}
+
+// fileContentAPIPath returns the API path of a file. Mage AI identifies files
+// by their escaped path, so that it is a single path segment.
+func fileContentAPIPath(filePath string) string {
+	return path.Join(FileContentsAPIPath, url.PathEscape(filePath))
+}
+
+// isFileNotFound reports whether err is the error of reading a file that does
+// not exist. Mage AI reports it as a 500 with the exception of Python, which
+// ErrNotFound does not match.
+func isFileNotFound(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && fileNotFoundException.MatchString(apiErr.Exception) {
+		return true
+	}
+	return IsNotFound(err)
+}
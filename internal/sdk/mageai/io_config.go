@@ -0,0 +1,212 @@
+package mageai
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// IOConfigFilePath is the path of the io_config.yaml file of a project,
+	// which holds the connection profiles of the blocks.
+	IOConfigFilePath = "io_config.yaml"
+	// IOConfigVersionKey is the top-level key of io_config.yaml holding the
+	// version of the file rather than a profile.
+	IOConfigVersionKey = "version"
+	// ioConfigVersion is the version written in a new io_config.yaml.
+	ioConfigVersion = "0.1.1"
+)
+
+type IOConfigAPI interface {
+	CreateIOConfigProfile(ctx context.Context, filePath *string, name *string, profile IOConfigProfile) error
+	DeleteIOConfigProfile(ctx context.Context, filePath *string, name *string) error
+	ReadIOConfigProfile(ctx context.Context, filePath *string, name *string) (IOConfigProfile, error)
+	UpdateIOConfigProfile(ctx context.Context, filePath *string, name *string, profile IOConfigProfile) error
+}
+
+// IOConfigProfile is a connection profile of io_config.yaml, mapping keys such
+// as POSTGRES_HOST to their values.
+type IOConfigProfile map[string]any
+
+// ioConfigLock serializes the changes of io_config.yaml files, which are read,
+// modified and written back as a whole, so that concurrent changes of
+// different profiles are not lost.
+type ioConfigLock struct {
+	mu sync.Mutex
+}
+
+// ReadIOConfigProfile returns the profile of io_config.yaml with the given
+// name.
+func (c *client) ReadIOConfigProfile(ctx context.Context, filePath *string, name *string) (IOConfigProfile, error) {
+	content, exists, err := c.readIOConfig(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("%s does not exist: %w", *filePath, ErrNotFound)
+	}
+
+	document, err := parseIOConfig(*filePath, content)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := document.Content[0]
+	i := ioConfigProfileIndex(profiles, *name)
+	if i < 0 {
+		return nil, fmt.Errorf("profile %s does not exist in %s: %w", *name, *filePath, ErrNotFound)
+	}
+
+	// Decoded into a plain map, since the nested mappings would otherwise be
+	// decoded into IOConfigProfile values
+	profile := map[string]any{}
+	if err := profiles.Content[i+1].Decode(&profile); err != nil {
+		return nil, fmt.Errorf("error parsing profile %s of %s: %w", *name, *filePath, err)
+	}
+	return IOConfigProfile(profile), nil
+}
+
+// CreateIOConfigProfile adds a profile to io_config.yaml, creating the file if
+// it does not exist. It fails when the profile already exists. The other
+// profiles, and their comments, are kept.
+func (c *client) CreateIOConfigProfile(ctx context.Context, filePath *string, name *string, profile IOConfigProfile) error {
+	return c.setIOConfigProfile(ctx, filePath, name, profile, true)
+}
+
+// UpdateIOConfigProfile creates or replaces the profile of io_config.yaml with
+// the given name, creating the file if it does not exist. The other profiles,
+// and their comments, are kept.
+func (c *client) UpdateIOConfigProfile(ctx context.Context, filePath *string, name *string, profile IOConfigProfile) error {
+	return c.setIOConfigProfile(ctx, filePath, name, profile, false)
+}
+
+func (c *client) setIOConfigProfile(ctx context.Context, filePath *string, name *string, profile IOConfigProfile, create bool) error {
+	c.ioConfigLock.mu.Lock()
+	defer c.ioConfigLock.mu.Unlock()
+
+	content, exists, err := c.readIOConfig(ctx, filePath)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		content = fmt.Sprintf("%s: %s\n", IOConfigVersionKey, ioConfigVersion)
+	}
+
+	document, err := parseIOConfig(*filePath, content)
+	if err != nil {
+		return err
+	}
+
+	value := &yaml.Node{}
+	if err := value.Encode(profile); err != nil {
+		return fmt.Errorf("error encoding profile %s: %w", *name, err)
+	}
+
+	profiles := document.Content[0]
+	if i := ioConfigProfileIndex(profiles, *name); i >= 0 {
+		if create {
+			return fmt.Errorf("profile %s already exists in %s: %w", *name, *filePath, ErrConflict)
+		}
+		profiles.Content[i+1] = value
+	} else {
+		key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: *name}
+		profiles.Content = append(profiles.Content, key, value)
+	}
+
+	return c.writeIOConfig(ctx, filePath, document)
+}
+
+// DeleteIOConfigProfile removes the profile of io_config.yaml with the given
+// name. The other profiles, and their comments, are kept.
+func (c *client) DeleteIOConfigProfile(ctx context.Context, filePath *string, name *string) error {
+	c.ioConfigLock.mu.Lock()
+	defer c.ioConfigLock.mu.Unlock()
+
+	content, exists, err := c.readIOConfig(ctx, filePath)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return fmt.Errorf("%s does not exist: %w", *filePath, ErrNotFound)
+	}
+
+	document, err := parseIOConfig(*filePath, content)
+	if err != nil {
+		return err
+	}
+
+	profiles := document.Content[0]
+	i := ioConfigProfileIndex(profiles, *name)
+	if i < 0 {
+		return fmt.Errorf("profile %s does not exist in %s: %w", *name, *filePath, ErrNotFound)
+	}
+	profiles.Content = append(profiles.Content[:i], profiles.Content[i+2:]...)
+
+	return c.writeIOConfig(ctx, filePath, document)
+}
+
+// readIOConfig returns the content of io_config.yaml, and whether the file
+// exists.
+func (c *client) readIOConfig(ctx context.Context, filePath *string) (string, bool, error) {
+	fileContent, err := c.ReadFileContent(ctx, filePath)
+	if err != nil {
+		if isFileNotFound(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return fileContent.Content, true, nil
+}
+
+func (c *client) writeIOConfig(ctx context.Context, filePath *string, document *yaml.Node) error {
+	var content bytes.Buffer
+	encoder := yaml.NewEncoder(&content)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return fmt.Errorf("error encoding %s: %w", *filePath, err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("error encoding %s: %w", *filePath, err)
+	}
+
+	_, err := c.UpdateFileContent(ctx, filePath, &UpdateFileContentRequest{
+		FileContent: FileContentRequest{Content: content.String()},
+	})
+	return err
+}
+
+// parseIOConfig parses io_config.yaml into a document whose only content is
+// the mapping of the profiles. An empty file has no profiles.
+func parseIOConfig(filePath, content string) (*yaml.Node, error) {
+	document := &yaml.Node{}
+	if err := yaml.Unmarshal([]byte(content), document); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", filePath, err)
+	}
+
+	if len(document.Content) == 0 {
+		document.Kind = yaml.DocumentNode
+		document.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+
+	if document.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("error parsing %s: expected a mapping of profiles", filePath)
+	}
+	return document, nil
+}
+
+// ioConfigProfileIndex returns the index of the key of the profile in the
+// mapping of the profiles, -1 if the profile does not exist.
+func ioConfigProfileIndex(profiles *yaml.Node, name string) int {
+	for i := 0; i+1 < len(profiles.Content); i += 2 {
+		if profiles.Content[i].Value == name && name != IOConfigVersionKey {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,229 @@
+package mageai_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+const testIOConfig = `version: 0.1.1
+# Connection of the local database
+default:
+  POSTGRES_DBNAME: postgres
+  POSTGRES_HOST: localhost # Overridden in production
+  POSTGRES_PASSWORD: "{{ env_var('POSTGRES_PASSWORD') }}"
+  POSTGRES_PORT: 5432
+dev:
+  SNOWFLAKE_ACCOUNT: example
+`
+
+func TestIOConfigProfileUpdateKeepsOtherProfiles(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.SetFile(mageai.IOConfigFilePath, testIOConfig)
+	client := newProjectTestClient(t, server, "")
+
+	filePath, name := mageai.IOConfigFilePath, "dev"
+	err := client.IOConfigAPI().UpdateIOConfigProfile(context.Background(), &filePath, &name, mageai.IOConfigProfile{
+		"SNOWFLAKE_USER":    "terraform",
+		"SNOWFLAKE_ACCOUNT": "example",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `version: 0.1.1
+# Connection of the local database
+default:
+  POSTGRES_DBNAME: postgres
+  POSTGRES_HOST: localhost # Overridden in production
+  POSTGRES_PASSWORD: "{{ env_var('POSTGRES_PASSWORD') }}"
+  POSTGRES_PORT: 5432
+dev:
+  SNOWFLAKE_ACCOUNT: example
+  SNOWFLAKE_USER: terraform
+`
+	if content, _ := server.File(mageai.IOConfigFilePath); content != expected {
+		t.Errorf("expected io config:\n%s\ngot:\n%s", expected, content)
+	}
+
+	profile, err := client.IOConfigAPI().ReadIOConfigProfile(context.Background(), &filePath, &name)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(profile) != 2 || profile["SNOWFLAKE_USER"] != "terraform" {
+		t.Errorf("unexpected profile: %v", profile)
+	}
+}
+
+func TestIOConfigProfileCreate(t *testing.T) {
+	testCases := map[string]struct {
+		missing  bool
+		content  string
+		expected string
+	}{
+		"missing file": {
+			missing:  true,
+			expected: "version: 0.1.1\nproduction:\n  POSTGRES_PORT: 5432\n",
+		},
+		"empty file": {
+			content:  "",
+			expected: "production:\n  POSTGRES_PORT: 5432\n",
+		},
+		"other profiles": {
+			content:  testIOConfig,
+			expected: testIOConfig + "production:\n  POSTGRES_PORT: 5432\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := mageaitest.NewServer(t)
+			if !testCase.missing {
+				server.SetFile(mageai.IOConfigFilePath, testCase.content)
+			}
+			client := newProjectTestClient(t, server, "")
+
+			filePath, name := mageai.IOConfigFilePath, "production"
+			err := client.IOConfigAPI().CreateIOConfigProfile(context.Background(), &filePath, &name, mageai.IOConfigProfile{"POSTGRES_PORT": 5432})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if content, _ := server.File(mageai.IOConfigFilePath); content != testCase.expected {
+				t.Errorf("expected io config:\n%s\ngot:\n%s", testCase.expected, content)
+			}
+		})
+	}
+}
+
+func TestIOConfigProfileMissingFile(t *testing.T) {
+	// The error Mage AI answers with when reading a missing file
+	const missingFile = `{"error": {"code": 500, "errors": ["Traceback (most recent call last):"], ` +
+		`"exception": "[Errno 2] No such file or directory: '/home/src/default_repo/io_config.yaml'", ` +
+		`"message": "Traceback (most recent call last):"}}`
+
+	var written string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(missingFile))
+			return
+		}
+
+		var req mageai.UpdateFileContentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		written = req.FileContent.Content
+		_, _ = w.Write([]byte(`{"file_content": {"path": "io_config.yaml"}}`))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+
+	filePath, name := mageai.IOConfigFilePath, "production"
+	if _, err := client.IOConfigAPI().ReadIOConfigProfile(context.Background(), &filePath, &name); !mageai.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	if err := client.IOConfigAPI().DeleteIOConfigProfile(context.Background(), &filePath, &name); !mageai.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	err := client.IOConfigAPI().CreateIOConfigProfile(context.Background(), &filePath, &name, mageai.IOConfigProfile{"POSTGRES_PORT": 5432})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := "version: 0.1.1\nproduction:\n  POSTGRES_PORT: 5432\n"; written != expected {
+		t.Errorf("expected io config:\n%s\ngot:\n%s", expected, written)
+	}
+}
+
+func TestIOConfigProfileCreateExisting(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.SetFile(mageai.IOConfigFilePath, testIOConfig)
+	client := newProjectTestClient(t, server, "")
+
+	filePath, name := mageai.IOConfigFilePath, "dev"
+	err := client.IOConfigAPI().CreateIOConfigProfile(context.Background(), &filePath, &name, mageai.IOConfigProfile{"SNOWFLAKE_USER": "terraform"})
+	if !mageai.IsConflict(err) {
+		t.Errorf("expected a conflict error, got %v", err)
+	}
+
+	if content, _ := server.File(mageai.IOConfigFilePath); content != testIOConfig {
+		t.Errorf("expected io config to be unchanged, got:\n%s", content)
+	}
+}
+
+func TestIOConfigProfileDelete(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.SetFile(mageai.IOConfigFilePath, testIOConfig)
+	client := newProjectTestClient(t, server, "")
+
+	filePath, name := mageai.IOConfigFilePath, "default"
+	if err := client.IOConfigAPI().DeleteIOConfigProfile(context.Background(), &filePath, &name); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "version: 0.1.1\ndev:\n  SNOWFLAKE_ACCOUNT: example\n"
+	if content, _ := server.File(mageai.IOConfigFilePath); content != expected {
+		t.Errorf("expected io config:\n%s\ngot:\n%s", expected, content)
+	}
+
+	_, err := client.IOConfigAPI().ReadIOConfigProfile(context.Background(), &filePath, &name)
+	if !mageai.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	err = client.IOConfigAPI().DeleteIOConfigProfile(context.Background(), &filePath, &name)
+	if !mageai.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestIOConfigProfileVersionIsNotAProfile(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.SetFile(mageai.IOConfigFilePath, testIOConfig)
+	client := newProjectTestClient(t, server, "")
+
+	filePath, name := mageai.IOConfigFilePath, mageai.IOConfigVersionKey
+	_, err := client.IOConfigAPI().ReadIOConfigProfile(context.Background(), &filePath, &name)
+	if !mageai.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestIOConfigProfileConcurrentUpdates(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	client := newProjectTestClient(t, server, "")
+
+	var wg sync.WaitGroup
+	for n := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			filePath, name := mageai.IOConfigFilePath, fmt.Sprintf("profile_%d", n)
+			err := client.IOConfigAPI().UpdateIOConfigProfile(context.Background(), &filePath, &name, mageai.IOConfigProfile{"POSTGRES_PORT": 5432})
+			if err != nil {
+				t.Errorf("updating profile %s: %v", name, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	filePath := mageai.IOConfigFilePath
+	for n := range 10 {
+		name := fmt.Sprintf("profile_%d", n)
+		if _, err := client.IOConfigAPI().ReadIOConfigProfile(context.Background(), &filePath, &name); err != nil {
+			t.Errorf("expected profile %s to exist, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package mageai
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const redactedValue = "***"
+
+// redactedHeaders are the request and response headers carrying credentials.
+var redactedHeaders = []string{"Authorization", "Cookie", "OAUTH-TOKEN", "Set-Cookie", "X-API-KEY"}
+
+// redactedKeys are the JSON keys and query parameters whose values are
+// credentials, in any request or response body.
+var redactedKeys = map[string]bool{
+	"api_key":     true,
+	"oauth_token": true,
+	"password":    true,
+	"token":       true,
+}
+
+// secretKeys are the JSON keys holding secrets, whose values are redacted.
+// The content of files is redacted too, since files such as io_config.yaml
+// hold the credentials of every connection profile.
+var secretKeys = map[string]bool{
+	"file_content":  true,
+	"file_contents": true,
+	"secret":        true,
+	"secrets":       true,
+}
+
+// loggingTransport logs the requests sent to Mage AI through tflog, with the
+// logger of the request context.
+type loggingTransport struct {
+	transport http.RoundTripper
+}
+
+// NewLoggingTransport returns an http.RoundTripper that logs the method, URL,
+// status and latency of every request at DEBUG level, and their headers and
+// bodies at TRACE level. Credentials and secret values are redacted. It can
+// be set as ClientConfig.WrapTransport.
+func NewLoggingTransport(transport http.RoundTripper) http.RoundTripper {
+	return &loggingTransport{transport: transport}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	fields := map[string]any{
+		"http_method": req.Method,
+		"http_url":    redactURL(req),
+	}
+
+	tflog.Debug(ctx, "Sending Mage AI API request", fields)
+	tflog.Trace(ctx, "Mage AI API request details", mergeFields(fields, map[string]any{
+		"http_headers": redactHeaders(req.Header),
+		"http_body":    redactBody(requestBody(req)),
+	}))
+
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	fields["http_duration_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		tflog.Debug(ctx, "Mage AI API request failed", mergeFields(fields, map[string]any{"error": err.Error()}))
+		return nil, err
+	}
+
+	fields["http_status"] = resp.StatusCode
+	tflog.Debug(ctx, "Received Mage AI API response", fields)
+
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.Trace(ctx, "Mage AI API response details", mergeFields(fields, map[string]any{
+		"http_headers": redactHeaders(resp.Header),
+		"http_body":    redactBody(respBody),
+	}))
+	return resp, nil
+}
+
+// requestBody returns a copy of the body of the request, without consuming
+// it as a RoundTripper must not modify the request.
+func requestBody(req *http.Request) []byte {
+	if req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil
+	}
+	return content
+}
+
+// readBody reads the body and replaces it with a copy, so that it can still
+// be read by the caller.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	content, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+
+	*body = io.NopCloser(bytes.NewReader(content))
+	return content, nil
+}
+
+func mergeFields(fields, additionalFields map[string]any) map[string]any {
+	merged := make(map[string]any, len(fields)+len(additionalFields))
+	for key, value := range fields {
+		merged[key] = value
+	}
+	for key, value := range additionalFields {
+		merged[key] = value
+	}
+	return merged
+}
+
+func redactURL(req *http.Request) string {
+	redacted := *req.URL
+	query := redacted.Query()
+	for key := range query {
+		if redactedKeys[strings.ToLower(key)] {
+			query.Set(key, redactedValue)
+		}
+	}
+	redacted.RawQuery = query.Encode()
+	return redacted.Redacted()
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	redacted := make(map[string]string, len(header))
+	for key := range header {
+		redacted[key] = header.Get(key)
+	}
+
+	for _, key := range redactedHeaders {
+		if key = http.CanonicalHeaderKey(key); header.Get(key) != "" {
+			redacted[key] = redactedValue
+		}
+	}
+	return redacted
+}
+
+// redactBody returns the body with the values of the credentials and secrets
+// redacted. Bodies that are not JSON are returned as is.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var content any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&content); err != nil {
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(redactJSON(content, false))
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
+
+// redactJSON redacts the credentials in a decoded JSON value, and every
+// value of the objects nested in a secret but their name.
+func redactJSON(content any, inSecret bool) any {
+	switch content := content.(type) {
+	case map[string]any:
+		for key, value := range content {
+			switch {
+			case redactedKeys[strings.ToLower(key)]:
+				content[key] = redactedValue
+			case secretKeys[strings.ToLower(key)]:
+				content[key] = redactJSON(value, true)
+			case inSecret && key != "name":
+				content[key] = redactedValue
+			default:
+				content[key] = redactJSON(value, inSecret)
+			}
+		}
+	case []any:
+		for i, value := range content {
+			content[i] = redactJSON(value, inSecret)
+		}
+	}
+	return content
+}
@@ -0,0 +1,145 @@
+package mageai_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func TestLoggingTransport(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.RequireUserAuthentication("mage", "p4ssw0rd", time.Hour)
+
+	client, err := mageai.New(&mageai.ClientConfig{
+		ApiKey:        mageaitest.APIKey,
+		Host:          server.URL,
+		Username:      "mage",
+		Password:      "p4ssw0rd",
+		WrapTransport: mageai.NewLoggingTransport,
+	})
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	_, err = client.SecretAPI().CreateSecret(ctx, &mageai.CreateSecretRequest{
+		Secret: mageai.SecretRequest{Name: "example_secret", Value: "s3cr3t-v4lu3"},
+	})
+	if err != nil {
+		t.Fatalf("creating secret: %v", err)
+	}
+
+	for _, leaked := range []string{mageaitest.APIKey, "p4ssw0rd", "s3cr3t-v4lu3"} {
+		if strings.Contains(output.String(), leaked) {
+			t.Errorf("expected %q to be redacted from the logs:\n%s", leaked, output.String())
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("decoding logs: %v", err)
+	}
+
+	var responses, details []map[string]any
+	for _, entry := range entries {
+		switch entry["@message"] {
+		case "Received Mage AI API response":
+			responses = append(responses, entry)
+		case "Mage AI API request details", "Mage AI API response details":
+			details = append(details, entry)
+		}
+	}
+
+	// The session is created before the secret
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses to be logged, got %d:\n%v", len(responses), entries)
+	}
+
+	for _, response := range responses {
+		if response["http_method"] != "POST" || response["http_status"] != float64(200) {
+			t.Errorf("expected a successful POST to be logged, got %v", response)
+		}
+
+		if _, ok := response["http_duration_ms"]; !ok {
+			t.Errorf("expected the latency to be logged, got %v", response)
+		}
+	}
+
+	expectedBodies := map[string]bool{
+		`{"session":{"password":"***","username":"mage"}}`:   false,
+		`{"secret":{"name":"example_secret","value":"***"}}`: false,
+	}
+	for _, entry := range details {
+		if body, ok := entry["http_body"].(string); ok {
+			if _, expected := expectedBodies[body]; expected {
+				expectedBodies[body] = true
+			}
+
+			if strings.Contains(body, `"token":`) && !strings.Contains(body, `"token":"***"`) {
+				t.Errorf("expected the session token to be redacted, got %s", body)
+			}
+		}
+
+		headers, _ := entry["http_headers"].(map[string]any)
+		for _, header := range []string{"Authorization", "Oauth-Token", "X-Api-Key"} {
+			if value, ok := headers[header]; ok && value != "***" {
+				t.Errorf("expected the %s header to be redacted, got %v", header, value)
+			}
+		}
+	}
+
+	for body, logged := range expectedBodies {
+		if !logged {
+			t.Errorf("expected the request body %s to be logged", body)
+		}
+	}
+}
+
+func TestLoggingTransportRedactsFileContents(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.SetFile(mageai.IOConfigFilePath, "default:\n  SNOWFLAKE_PASSWORD: unm4n4g3d-p4ssw0rd\n")
+
+	client, err := mageai.New(&mageai.ClientConfig{
+		ApiKey:        mageaitest.APIKey,
+		Host:          server.URL,
+		WrapTransport: mageai.NewLoggingTransport,
+	})
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	filePath, name := mageai.IOConfigFilePath, "warehouse"
+	err = client.IOConfigAPI().CreateIOConfigProfile(ctx, &filePath, &name, mageai.IOConfigProfile{"POSTGRES_PASSWORD": "p0stgr3s-p4ssw0rd"})
+	if err != nil {
+		t.Fatalf("creating profile: %v", err)
+	}
+
+	if _, err := client.IOConfigAPI().ReadIOConfigProfile(ctx, &filePath, &name); err != nil {
+		t.Fatalf("reading profile: %v", err)
+	}
+
+	// The file is logged when it is read and written, with the passwords of
+	// the managed profile and of the other profiles
+	for _, leaked := range []string{"p0stgr3s-p4ssw0rd", "unm4n4g3d-p4ssw0rd"} {
+		if strings.Contains(output.String(), leaked) {
+			t.Errorf("expected %q to be redacted from the logs:\n%s", leaked, output.String())
+		}
+	}
+
+	if !strings.Contains(output.String(), `\"content\":\"***\"`) {
+		t.Errorf("expected the file content to be logged redacted:\n%s", output.String())
+	}
+}
@@ -0,0 +1,61 @@
+package mageaitest
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// File returns the content of the stored file, and whether it exists.
+func (s *Server) File(filePath string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	content, ok := s.files[filePath]
+	return content, ok
+}
+
+// SetFile stores a file as if it was edited in the Mage AI UI.
+func (s *Server) SetFile(filePath, content string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.files[filePath] = content
+}
+
+func newFileContent(filePath, content string) mageai.FileContent {
+	return mageai.FileContent{
+		Content: content,
+		Name:    path.Base(filePath),
+		Path:    filePath,
+	}
+}
+
+func (s *Server) readFileContent(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filePath := r.PathValue("file")
+	content, ok := s.files[filePath]
+	if !ok {
+		writeServerError(w, fmt.Sprintf("[Errno 2] No such file or directory: '/home/src/default_repo/%s'", filePath))
+		return
+	}
+	writeJSON(w, map[string]any{"file_content": newFileContent(filePath, content)})
+}
+
+func (s *Server) updateFileContent(w http.ResponseWriter, r *http.Request) {
+	req := mageai.UpdateFileContentRequest{}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filePath := r.PathValue("file")
+	s.files[filePath] = req.FileContent.Content
+	writeJSON(w, map[string]any{"file_content": newFileContent(filePath, req.FileContent.Content)})
+}
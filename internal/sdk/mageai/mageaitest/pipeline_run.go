@@ -0,0 +1,163 @@
+package mageaitest
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// PipelineRun returns a copy of the stored pipeline run, and whether it
+// exists.
+func (s *Server) PipelineRun(id int64) (mageai.PipelineRun, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipelineRun, ok := s.pipelineRuns[id]
+	if !ok {
+		return mageai.PipelineRun{}, false
+	}
+	return *pipelineRun, true
+}
+
+// AddPipelineRun stores a pipeline run as if it was triggered outside of
+// Terraform, and returns it with its ID set.
+func (s *Server) AddPipelineRun(pipelineRun mageai.PipelineRun) mageai.PipelineRun {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pipelineRunID++
+	pipelineRun.ID = s.pipelineRunID
+	if pipelineRun.CreatedAt == "" {
+		pipelineRun.CreatedAt = now()
+	}
+	s.pipelineRuns[pipelineRun.ID] = &pipelineRun
+	return pipelineRun
+}
+
+// SetPipelineRunOutcome sets the status the pipeline runs end with. Every
+// time a run is read, it moves from initial to running, then from running to
+// the outcome. Without an outcome, runs keep running.
+func (s *Server) SetPipelineRunOutcome(status mageai.PipelineRunStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pipelineRunOutcome = status
+}
+
+// advancePipelineRun moves a pipeline run to its next status. s.mu must be
+// held.
+func (s *Server) advancePipelineRun(pipelineRun *mageai.PipelineRun) {
+	switch mageai.PipelineRunStatus(pipelineRun.Status) {
+	case mageai.InitialPipelineRunStatus:
+		pipelineRun.Status = string(mageai.RunningPipelineRunStatus)
+		pipelineRun.StartedAt = now()
+	case mageai.RunningPipelineRunStatus:
+		if s.pipelineRunOutcome != "" {
+			pipelineRun.Status = string(s.pipelineRunOutcome)
+			pipelineRun.CompletedAt = now()
+		}
+	default:
+		return
+	}
+	pipelineRun.UpdatedAt = now()
+}
+
+// matchesPipelineRunsQuery reports whether a pipeline run matches the filters
+// of a request listing pipeline runs.
+func matchesPipelineRunsQuery(pipelineRun *mageai.PipelineRun, r *http.Request) bool {
+	query := r.URL.Query()
+	if uuid := query.Get("pipeline_uuid"); uuid != "" && uuid != pipelineRun.PipelineUUID {
+		return false
+	}
+
+	if id := query.Get("pipeline_schedule_id"); id != "" && id != strconv.FormatInt(pipelineRun.PipelineScheduleID, 10) {
+		return false
+	}
+
+	if status := query.Get("status"); status != "" && status != pipelineRun.Status {
+		return false
+	}
+
+	createdAt, err := time.Parse(timeLayout, pipelineRun.CreatedAt)
+	if err != nil {
+		return false
+	}
+
+	if start, err := strconv.ParseInt(query.Get("start_timestamp"), 10, 64); err == nil && createdAt.Before(time.Unix(start, 0)) {
+		return false
+	}
+
+	if end, err := strconv.ParseInt(query.Get("end_timestamp"), 10, 64); err == nil && createdAt.After(time.Unix(end, 0)) {
+		return false
+	}
+	return true
+}
+
+func (s *Server) readPipelineRuns(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipelineRuns := []mageai.PipelineRun{}
+	for _, id := range slices.Sorted(maps.Keys(s.pipelineRuns)) {
+		if pipelineRun := s.pipelineRuns[id]; matchesPipelineRunsQuery(pipelineRun, r) {
+			pipelineRuns = append(pipelineRuns, *pipelineRun)
+		}
+	}
+	writeJSON(w, map[string]any{"pipeline_runs": pipelineRuns})
+}
+
+func (s *Server) createPipelineRun(w http.ResponseWriter, r *http.Request) {
+	req := mageai.CreatePipelineRunRequest{}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipelineSchedule, ok := s.lookupPipelineSchedule(w, r)
+	if !ok {
+		return
+	}
+
+	variables := maps.Clone(pipelineSchedule.Variables)
+	if variables == nil {
+		variables = map[string]any{}
+	}
+	maps.Copy(variables, req.PipelineRun.Variables)
+
+	s.pipelineRunID++
+	pipelineRun := &mageai.PipelineRun{
+		CreatedAt:            now(),
+		ExecutionDate:        now(),
+		ID:                   s.pipelineRunID,
+		PipelineScheduleID:   pipelineSchedule.ID,
+		PipelineScheduleName: pipelineSchedule.Name,
+		PipelineUUID:         pipelineSchedule.PipelineUUID,
+		Status:               string(mageai.InitialPipelineRunStatus),
+		UpdatedAt:            now(),
+		Variables:            variables,
+	}
+	s.pipelineRuns[pipelineRun.ID] = pipelineRun
+	writeJSON(w, map[string]any{"pipeline_run": pipelineRun})
+}
+
+func (s *Server) readPipelineRun(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, err := strconv.ParseInt(r.PathValue("run"), 10, 64)
+	pipelineRun, ok := s.pipelineRuns[id]
+	if err != nil || !ok {
+		writeNotFound(w, fmt.Sprintf("Pipeline run %s does not exist.", r.PathValue("run")))
+		return
+	}
+
+	s.advancePipelineRun(pipelineRun)
+	writeJSON(w, map[string]any{"pipeline_run": pipelineRun})
+}
@@ -0,0 +1,187 @@
+package mageaitest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// PipelineSchedule returns a copy of the stored pipeline schedule, and whether
+// it exists.
+func (s *Server) PipelineSchedule(id int64) (mageai.PipelineSchedule, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipelineSchedule, ok := s.pipelineSchedules[id]
+	if !ok {
+		return mageai.PipelineSchedule{}, false
+	}
+	return *pipelineSchedule, true
+}
+
+// DeletePipelineSchedule removes a pipeline schedule as if it was deleted in
+// the Mage AI UI.
+func (s *Server) DeletePipelineSchedule(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.pipelineSchedules, id)
+}
+
+// deletePipelineSchedules removes the schedules of a deleted pipeline. s.mu
+// must be held.
+func (s *Server) deletePipelineSchedules(pipelineUUID string) {
+	maps.DeleteFunc(s.pipelineSchedules, func(_ int64, ps *mageai.PipelineSchedule) bool {
+		return ps.PipelineUUID == pipelineUUID
+	})
+}
+
+// lookupPipelineSchedule returns the pipeline schedule of the request, writing
+// the not found error envelope if it does not exist. s.mu must be held.
+func (s *Server) lookupPipelineSchedule(w http.ResponseWriter, r *http.Request) (*mageai.PipelineSchedule, bool) {
+	id, err := strconv.ParseInt(r.PathValue("schedule"), 10, 64)
+	if err != nil {
+		writeNotFound(w, fmt.Sprintf("Pipeline schedule %s does not exist.", r.PathValue("schedule")))
+		return nil, false
+	}
+
+	pipelineSchedule, ok := s.pipelineSchedules[id]
+	if !ok {
+		writeNotFound(w, fmt.Sprintf("Pipeline schedule %d does not exist.", id))
+	}
+	return pipelineSchedule, ok
+}
+
+func newToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// normalizeStartTime returns the start time in UTC in the format Mage AI
+// returns it, e.g. 2024-01-01 00:00:00 for 2024-01-01T01:00:00+01:00. Start
+// times that cannot be parsed are kept as is.
+func normalizeStartTime(startTime string) string {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05Z07:00", "2006-01-02T15:04:05", "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, startTime); err == nil {
+			return t.UTC().Format("2006-01-02 15:04:05")
+		}
+	}
+	return startTime
+}
+
+func setPipelineScheduleFields(pipelineSchedule *mageai.PipelineSchedule, req mageai.PipelineScheduleRequest) {
+	pipelineSchedule.Description = req.Description
+	pipelineSchedule.Name = req.Name
+	pipelineSchedule.ScheduleInterval = req.ScheduleInterval
+	pipelineSchedule.ScheduleType = string(req.ScheduleType)
+	pipelineSchedule.Settings = req.Settings
+	pipelineSchedule.Sla = 0
+	if req.Sla != nil {
+		pipelineSchedule.Sla = *req.Sla
+	}
+	pipelineSchedule.StartTime = ""
+	if req.StartTime != nil {
+		pipelineSchedule.StartTime = normalizeStartTime(*req.StartTime)
+	}
+	pipelineSchedule.Status = string(req.Status)
+	pipelineSchedule.Variables = req.Variables
+	if pipelineSchedule.Variables == nil {
+		pipelineSchedule.Variables = map[string]any{}
+	}
+}
+
+func (s *Server) readPipelineSchedules(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipeline, ok := s.lookupPipeline(w, r)
+	if !ok {
+		return
+	}
+
+	pipelineSchedules := []mageai.PipelineSchedule{}
+	for _, id := range slices.Sorted(maps.Keys(s.pipelineSchedules)) {
+		if pipelineSchedule := s.pipelineSchedules[id]; pipelineSchedule.PipelineUUID == pipeline.UUID {
+			pipelineSchedules = append(pipelineSchedules, *pipelineSchedule)
+		}
+	}
+	writeJSON(w, map[string]any{"pipeline_schedules": pipelineSchedules})
+}
+
+func (s *Server) createPipelineSchedule(w http.ResponseWriter, r *http.Request) {
+	req := mageai.CreatePipelineScheduleRequest{}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipeline, ok := s.lookupPipeline(w, r)
+	if !ok {
+		return
+	}
+
+	s.pipelineScheduleID++
+	pipelineSchedule := &mageai.PipelineSchedule{
+		CreatedAt:    now(),
+		ID:           s.pipelineScheduleID,
+		PipelineUUID: pipeline.UUID,
+		Token:        newToken(),
+		UpdatedAt:    now(),
+	}
+	setPipelineScheduleFields(pipelineSchedule, req.PipelineSchedule)
+	s.pipelineSchedules[pipelineSchedule.ID] = pipelineSchedule
+	writeJSON(w, map[string]any{"pipeline_schedule": pipelineSchedule})
+}
+
+func (s *Server) readPipelineSchedule(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipelineSchedule, ok := s.lookupPipelineSchedule(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, map[string]any{"pipeline_schedule": pipelineSchedule})
+}
+
+func (s *Server) updatePipelineSchedule(w http.ResponseWriter, r *http.Request) {
+	req := mageai.UpdatePipelineScheduleRequest{}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipelineSchedule, ok := s.lookupPipelineSchedule(w, r)
+	if !ok {
+		return
+	}
+
+	setPipelineScheduleFields(pipelineSchedule, req.PipelineSchedule)
+	pipelineSchedule.UpdatedAt = now()
+	writeJSON(w, map[string]any{"pipeline_schedule": pipelineSchedule})
+}
+
+func (s *Server) deletePipelineSchedule(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipelineSchedule, ok := s.lookupPipelineSchedule(w, r)
+	if !ok {
+		return
+	}
+
+	delete(s.pipelineSchedules, pipelineSchedule.ID)
+	writeJSON(w, map[string]any{"pipeline_schedule": pipelineSchedule})
+}
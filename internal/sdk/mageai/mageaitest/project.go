@@ -0,0 +1,132 @@
+package mageaitest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// AddProjects makes the server a multi-project Mage AI instance with the given
+// projects, each with its own data. The data kept before a project is
+// activated belongs to the root project, which cannot be activated.
+func (s *Server) AddProjects(names ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, name := range names {
+		if _, ok := s.projects[name]; !ok && name != s.activeProject {
+			s.projects[name] = newProjectData()
+		}
+	}
+}
+
+// ActiveProject returns the name of the active project, empty when no project
+// was activated.
+func (s *Server) ActiveProject() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.activeProject
+}
+
+// ProjectActivations returns the number of times a project was activated.
+func (s *Server) ProjectActivations() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.projectActivations
+}
+
+// ProjectPipeline returns a copy of the pipeline stored in a project, and
+// whether it exists. The root project is named by an empty string.
+func (s *Server) ProjectPipeline(project, uuid string) (mageai.Pipeline, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data := &s.projectData
+	if project != s.activeProject {
+		var ok bool
+		if data, ok = s.projects[project]; !ok {
+			return mageai.Pipeline{}, false
+		}
+	}
+
+	pipeline, ok := data.pipelines[uuid]
+	if !ok {
+		return mageai.Pipeline{}, false
+	}
+	return *pipeline, true
+}
+
+// SetVersion changes the version of Mage AI the server reports.
+func (s *Server) SetVersion(version string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.version = version
+}
+
+// ServerInfoReads returns the number of times the project, which holds the
+// version of Mage AI, was read.
+func (s *Server) ServerInfoReads() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.serverInfoReads
+}
+
+// readProjects answers with the active project, as Mage AI does.
+func (s *Server) readProjects(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.serverInfoReads++
+
+	name, projectType := s.activeProject, "sub"
+	if name == "" {
+		name, projectType = "mageaitest", "standalone"
+		if len(s.projects) > 0 {
+			projectType = "main"
+		}
+	}
+
+	project := mageai.ServerInfo{
+		LatestVersion: s.version,
+		Name:          name,
+		ProjectType:   projectType,
+		ProjectUUID:   fmt.Sprintf("%x", name),
+		Version:       s.version,
+	}
+	writeJSON(w, map[string]any{"projects": []mageai.ServerInfo{project}})
+}
+
+// updateProject activates a project, after which the requests are answered
+// with its data.
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request) {
+	var req mageai.UpdateProjectRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := req.Project.ActivateProject
+	if name != s.activeProject {
+		data, ok := s.projects[name]
+		if !ok || name == "" {
+			writeBadRequest(w, fmt.Sprintf("Project %q does not exist.", name))
+			return
+		}
+
+		activeData := s.projectData
+		s.projects[s.activeProject] = &activeData
+		delete(s.projects, name)
+		s.projectData = *data
+		s.activeProject = name
+	}
+	s.projectActivations++
+
+	writeJSON(w, map[string]any{"project": map[string]any{"name": s.activeProject}})
+}
@@ -0,0 +1,75 @@
+package mageaitest
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// SecretValue returns the value of the stored secret, and whether it exists.
+func (s *Server) SecretValue(name string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.secrets[name]
+	return value, ok
+}
+
+// DeleteSecret removes a secret as if it was deleted in the Mage AI UI.
+func (s *Server) DeleteSecret(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.secrets, name)
+}
+
+func (s *Server) readSecrets(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	secrets := []mageai.Secret{}
+	for _, name := range slices.Sorted(maps.Keys(s.secrets)) {
+		secrets = append(secrets, mageai.Secret{Name: name})
+	}
+	writeJSON(w, map[string]any{"secrets": secrets})
+}
+
+func (s *Server) createSecret(w http.ResponseWriter, r *http.Request) {
+	req := mageai.CreateSecretRequest{}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.Secret.Name == "" {
+		writeBadRequest(w, "Secret name is required.")
+		return
+	}
+
+	if _, ok := s.secrets[req.Secret.Name]; ok {
+		writeBadRequest(w, fmt.Sprintf("Secret %s already exists.", req.Secret.Name))
+		return
+	}
+
+	s.secrets[req.Secret.Name] = req.Secret.Value
+	writeJSON(w, map[string]any{"secret": mageai.Secret{Name: req.Secret.Name}})
+}
+
+func (s *Server) deleteSecret(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := r.PathValue("secret")
+	if _, ok := s.secrets[name]; !ok {
+		writeNotFound(w, fmt.Sprintf("Secret %s does not exist.", name))
+		return
+	}
+
+	delete(s.secrets, name)
+	writeJSON(w, map[string]any{"secret": mageai.Secret{Name: name}})
+}
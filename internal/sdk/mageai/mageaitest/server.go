@@ -0,0 +1,613 @@
+// Package mageaitest provides an in-memory stand-in for the Mage AI API, for
+// use in tests of the SDK and the provider.
+package mageaitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode"
+
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// APIKey is the API key a Server accepts in the X-API-KEY header.
+const APIKey = "mageaitest"
+
+// Version is the version of Mage AI a Server reports, unless it is changed
+// with SetVersion.
+const Version = "0.9.76"
+
+// Server is an in-memory Mage AI API served over HTTP. It keeps pipelines,
+// their blocks, schedules and variables, secrets and files in memory and
+// answers with the response and error envelopes of Mage AI.
+type Server struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	// projectData is the data of the active project, the data of the other
+	// projects is kept in projects.
+	projectData
+	activeProject      string
+	blockTemplate      string
+	pipelineRunID      int64
+	pipelineUpdateErr  string
+	pipelineRunOutcome mageai.PipelineRunStatus
+	pipelineScheduleID int64
+	projectActivations int
+	projects           map[string]*projectData
+	serverInfoReads    int
+	userAuthentication *userAuthentication
+	version            string
+}
+
+// projectData is the data of a project of a multi-project Mage AI instance.
+type projectData struct {
+	blockVariables    map[string]map[string][]mageai.Variable
+	files             map[string]string
+	globalVariables   map[string]map[string]any
+	pipelineRuns      map[int64]*mageai.PipelineRun
+	pipelines         map[string]*mageai.Pipeline
+	pipelineSchedules map[int64]*mageai.PipelineSchedule
+	secrets           map[string]string
+}
+
+func newProjectData() *projectData {
+	return &projectData{
+		blockVariables:    map[string]map[string][]mageai.Variable{},
+		files:             map[string]string{},
+		globalVariables:   map[string]map[string]any{},
+		pipelineRuns:      map[int64]*mageai.PipelineRun{},
+		pipelines:         map[string]*mageai.Pipeline{},
+		pipelineSchedules: map[int64]*mageai.PipelineSchedule{},
+		secrets:           map[string]string{},
+	}
+}
+
+// NewServer starts a Server that is closed when the test finishes.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	s := newServer(t)
+	s.Start()
+	return s
+}
+
+// newServer returns a Server that is not started yet, so that its TLS
+// configuration can be set before it starts.
+func newServer(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{
+		projectData: *newProjectData(),
+		projects:    map[string]*projectData{},
+		version:     Version,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/file_contents/{file}", s.readFileContent)
+	mux.HandleFunc("PUT /api/file_contents/{file}", s.updateFileContent)
+	mux.HandleFunc("GET /api/pipelines", s.readPipelines)
+	mux.HandleFunc("POST /api/pipelines", s.createPipeline)
+	mux.HandleFunc("GET /api/pipelines/{pipeline}", s.readPipeline)
+	mux.HandleFunc("PUT /api/pipelines/{pipeline}", s.updatePipeline)
+	mux.HandleFunc("DELETE /api/pipelines/{pipeline}", s.deletePipeline)
+	mux.HandleFunc("GET /api/pipelines/{pipeline}/blocks", s.readBlocks)
+	mux.HandleFunc("POST /api/pipelines/{pipeline}/blocks", s.createBlock)
+	for _, blockPath := range []string{mageai.BlockAPIPath, mageai.BlocksAPIPath} {
+		mux.HandleFunc("GET /api/pipelines/{pipeline}/"+blockPath+"/{block...}", s.readBlock)
+		mux.HandleFunc("PUT /api/pipelines/{pipeline}/"+blockPath+"/{block...}", s.updateBlock)
+		mux.HandleFunc("DELETE /api/pipelines/{pipeline}/"+blockPath+"/{block...}", s.deleteBlock)
+	}
+	mux.HandleFunc("GET /api/pipelines/{pipeline}/pipeline_schedules", s.readPipelineSchedules)
+	mux.HandleFunc("POST /api/pipelines/{pipeline}/pipeline_schedules", s.createPipelineSchedule)
+	mux.HandleFunc("GET /api/pipelines/{pipeline}/variables", s.readVariables)
+	mux.HandleFunc("POST /api/pipelines/{pipeline}/variables", s.createVariable)
+	mux.HandleFunc("PUT /api/pipelines/{pipeline}/variables/{variable}", s.updateVariable)
+	mux.HandleFunc("DELETE /api/pipelines/{pipeline}/variables/{variable}", s.deleteVariable)
+	mux.HandleFunc("GET /api/pipeline_runs", s.readPipelineRuns)
+	mux.HandleFunc("GET /api/pipeline_runs/{run}", s.readPipelineRun)
+	mux.HandleFunc("GET /api/pipeline_schedules/{schedule}", s.readPipelineSchedule)
+	mux.HandleFunc("PUT /api/pipeline_schedules/{schedule}", s.updatePipelineSchedule)
+	mux.HandleFunc("DELETE /api/pipeline_schedules/{schedule}", s.deletePipelineSchedule)
+	mux.HandleFunc("POST /api/pipeline_schedules/{schedule}/pipeline_runs", s.createPipelineRun)
+	mux.HandleFunc("GET /api/projects", s.readProjects)
+	mux.HandleFunc("PUT /api/projects/{project}", s.updateProject)
+	mux.HandleFunc("POST /api/sessions", s.createSession)
+	mux.HandleFunc("GET /api/secrets", s.readSecrets)
+	mux.HandleFunc("POST /api/secrets", s.createSecret)
+	mux.HandleFunc("DELETE /api/secrets/{secret}", s.deleteSecret)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeNotFound(w, fmt.Sprintf("Route %s %s does not exist.", r.Method, r.URL.Path))
+	})
+
+	s.Server = httptest.NewUnstartedServer(requireAPIKey(s.requireSession(mux)))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Pipeline returns a copy of the stored pipeline, and whether it exists.
+func (s *Server) Pipeline(uuid string) (mageai.Pipeline, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipeline, ok := s.pipelines[uuid]
+	if !ok {
+		return mageai.Pipeline{}, false
+	}
+	return *pipeline, true
+}
+
+// SetBlockTemplate sets the content of the blocks created without content,
+// as Mage AI writes the template of the block type in them.
+func (s *Server) SetBlockTemplate(content string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.blockTemplate = content
+}
+
+// RejectPipelineUpdates makes the updates of pipelines fail with a bad request
+// error with the given exception, until it is called with an empty exception.
+func (s *Server) RejectPipelineUpdates(exception string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pipelineUpdateErr = exception
+}
+
+// DeletePipeline removes a pipeline as if it was deleted in the Mage AI UI.
+func (s *Server) DeletePipeline(uuid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.pipelines, uuid)
+	s.deletePipelineSchedules(uuid)
+	s.deleteVariables(uuid)
+}
+
+// DeleteBlock removes a block as if it was deleted in the Mage AI UI.
+func (s *Server) DeleteBlock(pipelineUUID, blockUUID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if pipeline, ok := s.pipelines[pipelineUUID]; ok {
+		removeBlock(pipeline, blockUUID)
+	}
+}
+
+// SetBlockContent replaces the content of a block as if it was edited in the
+// Mage AI UI, or rewritten by Mage AI.
+func (s *Server) SetBlockContent(pipelineUUID, blockUUID, content string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipeline, ok := s.pipelines[pipelineUUID]
+	if !ok {
+		return
+	}
+
+	for i := range pipeline.Blocks {
+		if pipeline.Blocks[i].UUID == blockUUID {
+			pipeline.Blocks[i].Content = content
+		}
+	}
+}
+
+// requireAPIKey answers with the Mage AI error envelope when the request does
+// not have the API key of the server.
+// cleanName returns the UUID of a pipeline or a block, line by line like
+// clean_name of mage_ai/shared/utils.py. It is not shared with
+// mageai.CleanName, so that the tests catch the UUIDs the provider gets wrong.
+func cleanName(name string) string {
+	for _, c := range []string{"\ufeff", "\u200b", "\u00a0"} {
+		name = strings.ReplaceAll(name, c, "")
+	}
+
+	for _, c := range "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~ " {
+		name = strings.ReplaceAll(name, string(c), "_")
+	}
+
+	if name != "" && unicode.IsDigit([]rune(name)[0]) {
+		name = "letter_" + name
+	}
+	return strings.ToLower(name)
+}
+
+func requireAPIKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-KEY") != APIKey {
+			writeError(w, http.StatusUnauthorized, "API key is invalid.", "invalid_api_key", "")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// timeLayout is the layout of the timestamps of Mage AI.
+const timeLayout = "2006-01-02 15:04:05.000000-07:00"
+
+func now() string {
+	return time.Now().UTC().Format(timeLayout)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes the Mage AI error envelope. Like Mage AI, errors are sent
+// with a 200 status code and the error code in the envelope.
+func writeError(w http.ResponseWriter, code int, message, errorType, exception string) {
+	writeJSON(w, map[string]any{
+		"error": map[string]any{
+			"code":      code,
+			"errors":    []string{message},
+			"exception": exception,
+			"message":   message,
+			"type":      errorType,
+		},
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, exception string) {
+	writeError(w, http.StatusNotFound, "Record not found.", "record_not_found", exception)
+}
+
+// writeServerError answers with the error of an exception Mage AI does not
+// handle, e.g. a missing file.
+func writeServerError(w http.ResponseWriter, exception string) {
+	writeError(w, http.StatusInternalServerError, exception, "", exception)
+}
+
+func writeBadRequest(w http.ResponseWriter, exception string) {
+	writeError(w, http.StatusBadRequest, "Bad request.", "bad_request", exception)
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeBadRequest(w, fmt.Sprintf("Invalid request body: %s", err))
+		return false
+	}
+	return true
+}
+
+// lookupPipeline returns the pipeline of the request, writing the not found
+// error envelope if it does not exist. s.mu must be held.
+func (s *Server) lookupPipeline(w http.ResponseWriter, r *http.Request) (*mageai.Pipeline, bool) {
+	uuid := r.PathValue("pipeline")
+	pipeline, ok := s.pipelines[uuid]
+	if !ok {
+		writeNotFound(w, fmt.Sprintf("Pipeline %s does not exist.", uuid))
+	}
+	return pipeline, ok
+}
+
+// lookupBlock returns the block of the request, writing the not found error
+// envelope if it does not exist. s.mu must be held.
+func (s *Server) lookupBlock(w http.ResponseWriter, r *http.Request) (*mageai.Pipeline, int, bool) {
+	pipeline, ok := s.lookupPipeline(w, r)
+	if !ok {
+		return nil, 0, false
+	}
+
+	uuid := r.PathValue("block")
+	index := slices.IndexFunc(pipeline.Blocks, func(b mageai.Block) bool { return b.UUID == uuid })
+	if index < 0 {
+		writeNotFound(w, fmt.Sprintf("Block %s does not exist in pipeline %s.", uuid, pipeline.UUID))
+		return nil, 0, false
+	}
+	return pipeline, index, true
+}
+
+func (s *Server) readPipelines(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	pipelines := []mageai.Pipeline{}
+	for _, pipeline := range s.pipelines {
+		schedules := s.schedulesOf(pipeline.UUID)
+		if !matchesPipelinesQuery(pipeline, schedules, query) {
+			continue
+		}
+
+		if query.Get("include_schedules") != "" {
+			pipelineWithSchedules := *pipeline
+			pipelineWithSchedules.Schedules = schedules
+			pipeline = &pipelineWithSchedules
+		}
+		pipelines = append(pipelines, *pipeline)
+	}
+	slices.SortFunc(pipelines, func(a, b mageai.Pipeline) int { return strings.Compare(a.UUID, b.UUID) })
+	writeJSON(w, map[string]any{"pipelines": pipelines})
+}
+
+// schedulesOf returns the schedules of a pipeline, ordered by ID. s.mu must
+// be held.
+func (s *Server) schedulesOf(pipelineUUID string) []mageai.PipelineSchedule {
+	schedules := []mageai.PipelineSchedule{}
+	for _, schedule := range s.pipelineSchedules {
+		if schedule.PipelineUUID == pipelineUUID {
+			schedules = append(schedules, *schedule)
+		}
+	}
+	slices.SortFunc(schedules, func(a, b mageai.PipelineSchedule) int { return int(a.ID - b.ID) })
+	return schedules
+}
+
+// matchesPipelinesQuery reports whether a pipeline matches the type[], tag[]
+// and status[] filters of a request listing pipelines. Like Mage AI, a
+// pipeline matches the tag filter when it has any of the tags.
+func matchesPipelinesQuery(pipeline *mageai.Pipeline, schedules []mageai.PipelineSchedule, query url.Values) bool {
+	if types := query["type[]"]; len(types) > 0 && !slices.Contains(types, pipeline.Type) {
+		return false
+	}
+
+	if tags := query["tag[]"]; len(tags) > 0 && !slices.ContainsFunc(tags, func(tag string) bool { return slices.Contains(pipeline.Tags, tag) }) {
+		return false
+	}
+
+	if statuses := query["status[]"]; len(statuses) > 0 && !slices.Contains(statuses, string(mageai.PipelineStatusOf(schedules))) {
+		return false
+	}
+	return true
+}
+
+func (s *Server) createPipeline(w http.ResponseWriter, r *http.Request) {
+	req := mageai.CreatePipelineRequest{}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	uuid := cleanName(req.Pipeline.Name)
+	if _, ok := s.pipelines[uuid]; ok {
+		writeBadRequest(w, fmt.Sprintf("Pipeline %s already exists.", uuid))
+		return
+	}
+
+	pipeline := &mageai.Pipeline{
+		Blocks:        []mageai.Block{},
+		CreatedAt:     now(),
+		ExecutorCount: 1,
+		Name:          req.Pipeline.Name,
+		Tags:          []string{},
+		Type:          string(req.Pipeline.Type),
+		UUID:          uuid,
+		UpdatedAt:     now(),
+		VariablesDir:  "/home/src/mage_data/default_repo",
+	}
+	setPipelineSettings(pipeline, req.Pipeline)
+	s.pipelines[uuid] = pipeline
+	writeJSON(w, map[string]any{"pipeline": pipeline})
+}
+
+func (s *Server) readPipeline(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipeline, ok := s.lookupPipeline(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, map[string]any{"pipeline": pipeline})
+}
+
+func (s *Server) updatePipeline(w http.ResponseWriter, r *http.Request) {
+	req := mageai.UpdatePipelineRequest{}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipeline, ok := s.lookupPipeline(w, r)
+	if !ok {
+		return
+	}
+
+	if s.pipelineUpdateErr != "" {
+		writeBadRequest(w, s.pipelineUpdateErr)
+		return
+	}
+
+	pipeline.Name = req.Pipeline.Name
+	pipeline.Type = string(req.Pipeline.Type)
+	pipeline.UpdatedAt = now()
+	setPipelineSettings(pipeline, req.Pipeline)
+	writeJSON(w, map[string]any{"pipeline": pipeline})
+}
+
+// setPipelineSettings copies the optional fields that are set in the request,
+// leaving the others unchanged.
+func setPipelineSettings(pipeline *mageai.Pipeline, req mageai.PipelineRequest) {
+	if req.CacheBlockOutputInMemory != nil {
+		pipeline.CacheBlockOutputInMemory = *req.CacheBlockOutputInMemory
+	}
+	if req.Description != nil {
+		pipeline.Description = *req.Description
+	}
+	if req.ExecutorCount != nil {
+		pipeline.ExecutorCount = *req.ExecutorCount
+	}
+	if req.RetryConfig != nil {
+		pipeline.RetryConfig = *req.RetryConfig
+	}
+	if req.RunPipelineInOneProcess != nil {
+		pipeline.RunPipelineInOneProcess = *req.RunPipelineInOneProcess
+	}
+	if req.Tags != nil {
+		pipeline.Tags = *req.Tags
+	}
+}
+
+func (s *Server) deletePipeline(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipeline, ok := s.lookupPipeline(w, r)
+	if !ok {
+		return
+	}
+
+	delete(s.pipelines, pipeline.UUID)
+	s.deletePipelineSchedules(pipeline.UUID)
+	s.deleteVariables(pipeline.UUID)
+	writeJSON(w, map[string]any{"pipeline": pipeline})
+}
+
+func (s *Server) readBlocks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipeline, ok := s.lookupPipeline(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, map[string]any{"blocks": pipeline.Blocks})
+}
+
+func (s *Server) createBlock(w http.ResponseWriter, r *http.Request) {
+	req := mageai.CreateBlockRequest{}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipeline, ok := s.lookupPipeline(w, r)
+	if !ok {
+		return
+	}
+
+	uuid := cleanName(req.Block.Name)
+	if slices.ContainsFunc(pipeline.Blocks, func(b mageai.Block) bool { return b.UUID == uuid }) {
+		writeBadRequest(w, fmt.Sprintf("Block %s already exists in pipeline %s.", uuid, pipeline.UUID))
+		return
+	}
+
+	block := mageai.Block{
+		Configuration:    req.Block.Configuration,
+		Content:          req.Block.Content,
+		DownstreamBlocks: []string{},
+		ExecutorType:     "local_python",
+		ExtensionUUID:    req.Block.ExtensionUUID,
+		Language:         req.Block.Language,
+		Name:             req.Block.Name,
+		Pipelines:        []string{pipeline.UUID},
+		Priority:         req.Block.Priority,
+		RetryConfig:      req.Block.RetryConfig,
+		Status:           "not_executed",
+		Type:             string(req.Block.Type),
+		UUID:             uuid,
+	}
+	if block.Language == "" {
+		block.Language = "python"
+	}
+	if block.Content == "" {
+		block.Content = s.blockTemplate
+	}
+	pipeline.Blocks = append(pipeline.Blocks, block)
+	setUpstreamBlocks(pipeline, uuid, req.Block.UpstreamBlocks)
+	writeJSON(w, map[string]any{"block": pipeline.Blocks[len(pipeline.Blocks)-1]})
+}
+
+func (s *Server) readBlock(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipeline, index, ok := s.lookupBlock(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, map[string]any{"block": pipeline.Blocks[index]})
+}
+
+func (s *Server) updateBlock(w http.ResponseWriter, r *http.Request) {
+	req := mageai.UpdateBlockRequest{}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipeline, index, ok := s.lookupBlock(w, r)
+	if !ok {
+		return
+	}
+
+	block := &pipeline.Blocks[index]
+	block.Configuration = req.Block.Configuration
+	block.Content = req.Block.Content
+	block.ExtensionUUID = req.Block.ExtensionUUID
+	block.Name = req.Block.Name
+	block.Priority = req.Block.Priority
+	block.Status = "updated"
+	if req.Block.Language != "" {
+		block.Language = req.Block.Language
+	}
+	setUpstreamBlocks(pipeline, block.UUID, req.Block.UpstreamBlocks)
+	writeJSON(w, map[string]any{"block": pipeline.Blocks[index]})
+}
+
+func (s *Server) deleteBlock(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipeline, index, ok := s.lookupBlock(w, r)
+	if !ok {
+		return
+	}
+
+	// Like Mage AI, a block that other blocks depend on is only deleted when
+	// forced
+	block := pipeline.Blocks[index]
+	if len(block.DownstreamBlocks) > 0 && r.URL.Query().Get("force") != "true" {
+		writeBadRequest(w, fmt.Sprintf("Block %s has downstream dependencies %s. Please remove the dependencies before deleting the block.", block.UUID, strings.Join(block.DownstreamBlocks, ", ")))
+		return
+	}
+
+	removeBlock(pipeline, block.UUID)
+	writeJSON(w, map[string]any{"block": block})
+}
+
+// setUpstreamBlocks replaces the upstream blocks of a block and keeps the
+// downstream blocks of the other blocks of the pipeline in sync.
+func setUpstreamBlocks(pipeline *mageai.Pipeline, uuid string, upstreamBlocks []string) {
+	for i := range pipeline.Blocks {
+		block := &pipeline.Blocks[i]
+		if block.UUID == uuid {
+			block.UpstreamBlocks = append([]string{}, upstreamBlocks...)
+			continue
+		}
+
+		block.DownstreamBlocks = slices.DeleteFunc(block.DownstreamBlocks, func(b string) bool { return b == uuid })
+		if slices.Contains(upstreamBlocks, block.UUID) {
+			block.DownstreamBlocks = append(block.DownstreamBlocks, uuid)
+		}
+	}
+}
+
+// removeBlock removes a block from a pipeline and from the upstream and
+// downstream blocks of the remaining blocks.
+func removeBlock(pipeline *mageai.Pipeline, uuid string) {
+	pipeline.Blocks = slices.DeleteFunc(pipeline.Blocks, func(b mageai.Block) bool { return b.UUID == uuid })
+	for i := range pipeline.Blocks {
+		block := &pipeline.Blocks[i]
+		block.DownstreamBlocks = slices.DeleteFunc(block.DownstreamBlocks, func(b string) bool { return b == uuid })
+		block.UpstreamBlocks = slices.DeleteFunc(block.UpstreamBlocks, func(b string) bool { return b == uuid })
+	}
+}
@@ -0,0 +1,247 @@
+package mageaitest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func newTestClient(t *testing.T, server *mageaitest.Server, apiKey string) mageai.Client {
+	t.Helper()
+
+	client, err := mageai.New(&mageai.ClientConfig{Host: server.URL, ApiKey: apiKey})
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	t.Cleanup(client.Close)
+	return client
+}
+
+func TestServerRequiresAPIKey(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	client := newTestClient(t, server, "invalid")
+
+	_, err := client.PipelineAPI().ReadPipelines(context.Background(), nil)
+	if !mageai.IsUnauthorized(err) {
+		t.Fatalf("expected an unauthorized error, got %v", err)
+	}
+}
+
+func TestServerNotFound(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	client := newTestClient(t, server, mageaitest.APIKey)
+	ctx := context.Background()
+
+	pipelineUUID := "missing_pipeline"
+	if _, err := client.PipelineAPI().ReadPipeline(ctx, &pipelineUUID); !mageai.IsNotFound(err) {
+		t.Errorf("expected a not found error for the pipeline, got %v", err)
+	}
+
+	blockUUID := "missing_block"
+	if _, err := client.BlockAPI().ReadBlock(ctx, &pipelineUUID, &blockUUID); !mageai.IsNotFound(err) {
+		t.Errorf("expected a not found error for the block, got %v", err)
+	}
+
+	var id int64 = 1
+	if _, err := client.PipelineScheduleAPI().ReadPipelineSchedule(ctx, &id); !mageai.IsNotFound(err) {
+		t.Errorf("expected a not found error for the pipeline schedule, got %v", err)
+	}
+}
+
+func TestServerDeletePipelineDeletesSchedules(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	client := newTestClient(t, server, mageaitest.APIKey)
+	ctx := context.Background()
+
+	createPipelineResponse, err := client.PipelineAPI().CreatePipeline(ctx, &mageai.CreatePipelineRequest{
+		Pipeline: mageai.PipelineRequest{Name: "Example Pipeline", Type: "python"},
+	})
+	if err != nil {
+		t.Fatalf("creating pipeline: %v", err)
+	}
+
+	pipelineUUID := createPipelineResponse.Pipeline.UUID
+	if pipelineUUID != "example_pipeline" {
+		t.Errorf("expected uuid example_pipeline, got %s", pipelineUUID)
+	}
+
+	createPipelineScheduleResponse, err := client.PipelineScheduleAPI().CreatePipelineSchedule(ctx, &pipelineUUID, &mageai.CreatePipelineScheduleRequest{
+		PipelineSchedule: mageai.PipelineScheduleRequest{Name: "example_trigger", ScheduleType: "api", Status: "active"},
+	})
+	if err != nil {
+		t.Fatalf("creating pipeline schedule: %v", err)
+	}
+
+	id := createPipelineScheduleResponse.PipelineSchedule.ID
+	if _, ok := server.PipelineSchedule(id); !ok {
+		t.Fatalf("expected pipeline schedule %d to exist", id)
+	}
+
+	if err := client.PipelineAPI().DeletePipeline(ctx, &pipelineUUID); err != nil {
+		t.Fatalf("deleting pipeline: %v", err)
+	}
+
+	if _, ok := server.PipelineSchedule(id); ok {
+		t.Errorf("expected pipeline schedule %d to be deleted with its pipeline", id)
+	}
+}
+
+func TestServerVariables(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	client := newTestClient(t, server, mageaitest.APIKey)
+	ctx := context.Background()
+
+	createPipelineResponse, err := client.PipelineAPI().CreatePipeline(ctx, &mageai.CreatePipelineRequest{
+		Pipeline: mageai.PipelineRequest{Name: "example_pipeline", Type: "python"},
+	})
+	if err != nil {
+		t.Fatalf("creating pipeline: %v", err)
+	}
+	pipelineUUID := createPipelineResponse.Pipeline.UUID
+
+	err = client.VariableAPI().CreateVariable(ctx, &pipelineUUID, &mageai.CreateVariableRequest{
+		Variable: mageai.VariableRequest{Name: "env", Value: "dev"},
+	})
+	if err != nil {
+		t.Fatalf("creating variable: %v", err)
+	}
+
+	name := "env"
+	variable, err := client.VariableAPI().ReadVariable(ctx, &pipelineUUID, &name)
+	if err != nil {
+		t.Fatalf("reading variable: %v", err)
+	}
+
+	if variable.Value != "dev" || variable.Type != "global" {
+		t.Errorf("expected global variable with value dev, got %+v", variable)
+	}
+
+	err = client.VariableAPI().CreateVariable(ctx, &pipelineUUID, &mageai.CreateVariableRequest{
+		Variable: mageai.VariableRequest{Name: "invalid-name", Value: "dev"},
+	})
+	if err == nil {
+		t.Errorf("expected an error for an invalid variable name")
+	}
+
+	if err := client.VariableAPI().DeleteVariable(ctx, &pipelineUUID, &name); err != nil {
+		t.Fatalf("deleting variable: %v", err)
+	}
+
+	if _, err := client.VariableAPI().ReadVariable(ctx, &pipelineUUID, &name); !mageai.IsNotFound(err) {
+		t.Errorf("expected a not found error for the deleted variable, got %v", err)
+	}
+}
+
+func TestServerPipelineRuns(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.SetPipelineRunOutcome(mageai.CompletedPipelineRunStatus)
+	client := newTestClient(t, server, mageaitest.APIKey)
+	ctx := context.Background()
+
+	createPipelineResponse, err := client.PipelineAPI().CreatePipeline(ctx, &mageai.CreatePipelineRequest{
+		Pipeline: mageai.PipelineRequest{Name: "example_pipeline", Type: "python"},
+	})
+	if err != nil {
+		t.Fatalf("creating pipeline: %v", err)
+	}
+	pipelineUUID := createPipelineResponse.Pipeline.UUID
+
+	createPipelineScheduleResponse, err := client.PipelineScheduleAPI().CreatePipelineSchedule(ctx, &pipelineUUID, &mageai.CreatePipelineScheduleRequest{
+		PipelineSchedule: mageai.PipelineScheduleRequest{Name: "example_trigger", ScheduleType: "api", Status: "active", Variables: map[string]any{"env": "dev"}},
+	})
+	if err != nil {
+		t.Fatalf("creating pipeline schedule: %v", err)
+	}
+	pipelineScheduleID := createPipelineScheduleResponse.PipelineSchedule.ID
+
+	createPipelineRunResponse, err := client.PipelineRunAPI().CreatePipelineRun(ctx, &pipelineScheduleID, &mageai.CreatePipelineRunRequest{
+		PipelineRun: mageai.PipelineRunRequest{Variables: map[string]any{"date": "2024-01-01"}},
+	})
+	if err != nil {
+		t.Fatalf("creating pipeline run: %v", err)
+	}
+
+	pipelineRun := createPipelineRunResponse.PipelineRun
+	if pipelineRun.PipelineUUID != pipelineUUID || pipelineRun.Variables["env"] != "dev" || pipelineRun.Variables["date"] != "2024-01-01" {
+		t.Errorf("expected a run of %s with the schedule and run variables, got %+v", pipelineUUID, pipelineRun)
+	}
+
+	for _, expectedStatus := range []mageai.PipelineRunStatus{mageai.RunningPipelineRunStatus, mageai.CompletedPipelineRunStatus, mageai.CompletedPipelineRunStatus} {
+		readPipelineRunResponse, err := client.PipelineRunAPI().ReadPipelineRun(ctx, &pipelineRun.ID)
+		if err != nil {
+			t.Fatalf("reading pipeline run: %v", err)
+		}
+
+		if status := readPipelineRunResponse.PipelineRun.Status; status != string(expectedStatus) {
+			t.Errorf("expected status %s, got %s", expectedStatus, status)
+		}
+	}
+
+	server.AddPipelineRun(mageai.PipelineRun{PipelineUUID: "other_pipeline", Status: "failed"})
+	server.AddPipelineRun(mageai.PipelineRun{CreatedAt: "2024-01-01 00:00:00.000000+00:00", PipelineUUID: pipelineUUID, Status: "failed"})
+
+	testCases := map[string]struct {
+		filter        mageai.PipelineRunsFilter
+		expectedCount int
+	}{
+		"all":          {expectedCount: 3},
+		"pipeline":     {filter: mageai.PipelineRunsFilter{PipelineUUID: pipelineUUID}, expectedCount: 2},
+		"status":       {filter: mageai.PipelineRunsFilter{Status: mageai.FailedPipelineRunStatus}, expectedCount: 2},
+		"created from": {filter: mageai.PipelineRunsFilter{CreatedAfter: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}, expectedCount: 2},
+		"created to":   {filter: mageai.PipelineRunsFilter{CreatedBefore: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}, expectedCount: 1},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			readPipelineRunsResponse, err := client.PipelineRunAPI().ReadPipelineRuns(ctx, &testCase.filter)
+			if err != nil {
+				t.Fatalf("reading pipeline runs: %v", err)
+			}
+
+			if count := len(readPipelineRunsResponse.PipelineRuns); count != testCase.expectedCount {
+				t.Errorf("expected %d pipeline runs, got %d", testCase.expectedCount, count)
+			}
+		})
+	}
+}
+
+func TestServerDeleteBlockWithDownstreamBlocks(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	client := newTestClient(t, server, mageaitest.APIKey)
+	ctx := context.Background()
+
+	pipelineUUID := "example_pipeline"
+	if _, err := client.PipelineAPI().CreatePipeline(ctx, &mageai.CreatePipelineRequest{
+		Pipeline: mageai.PipelineRequest{Name: pipelineUUID, Type: "python"},
+	}); err != nil {
+		t.Fatalf("creating pipeline: %v", err)
+	}
+
+	for _, block := range []mageai.BlockRequest{
+		{Name: "load", Type: "data_loader"},
+		{Name: "transform", Type: "transformer", UpstreamBlocks: []string{"load"}},
+	} {
+		if _, err := client.BlockAPI().CreateBlock(ctx, &pipelineUUID, &mageai.CreateBlockRequest{Block: block}); err != nil {
+			t.Fatalf("creating block %s: %v", block.Name, err)
+		}
+	}
+
+	upstreamUUID, downstreamUUID := "load", "transform"
+	var apiErr *mageai.APIError
+	if err := client.BlockAPI().DeleteBlock(ctx, &pipelineUUID, &upstreamUUID); !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		t.Fatalf("expected a bad request error deleting a block with downstream blocks, got %v", err)
+	}
+
+	if err := client.BlockAPI().DeleteBlock(ctx, &pipelineUUID, &downstreamUUID); err != nil {
+		t.Fatalf("deleting the downstream block: %v", err)
+	}
+
+	if err := client.BlockAPI().DeleteBlock(ctx, &pipelineUUID, &upstreamUUID); err != nil {
+		t.Fatalf("deleting the upstream block once it has no downstream blocks: %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package mageaitest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+// userAuthentication holds the user and the sessions of a Server that
+// requires user authentication, as Mage AI does with
+// REQUIRE_USER_AUTHENTICATION.
+type userAuthentication struct {
+	username   string
+	password   string
+	sessionTTL time.Duration
+	sessions   map[string]time.Time
+	created    int
+}
+
+// RequireUserAuthentication makes the server require, in addition to the API
+// key, a session token created with the given username and password. The
+// sessions expire after ttl.
+func (s *Server) RequireUserAuthentication(username, password string, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.userAuthentication = &userAuthentication{
+		username:   username,
+		password:   password,
+		sessionTTL: ttl,
+		sessions:   map[string]time.Time{},
+	}
+}
+
+// ExpireSessions expires the session tokens created so far.
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.userAuthentication != nil {
+		for token := range s.userAuthentication.sessions {
+			s.userAuthentication.sessions[token] = time.Now()
+		}
+	}
+}
+
+// SessionsCreated returns the number of sessions created so far.
+func (s *Server) SessionsCreated() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.userAuthentication == nil {
+		return 0
+	}
+	return s.userAuthentication.created
+}
+
+// requireSession answers with the Mage AI error envelope when the server
+// requires user authentication and the request does not have a valid session
+// token.
+func (s *Server) requireSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		auth := s.userAuthentication
+		var expiresAt time.Time
+		var ok bool
+		if auth != nil {
+			expiresAt, ok = auth.sessions[sessionToken(r)]
+		}
+		s.mu.Unlock()
+
+		switch {
+		case auth == nil || r.URL.Path == "/api/sessions":
+			next.ServeHTTP(w, r)
+		case !ok:
+			writeError(w, http.StatusUnauthorized, "Invalid OAuth token.", "invalid_oauth_token", "")
+		case !time.Now().Before(expiresAt):
+			writeError(w, http.StatusUnauthorized, "Expired OAuth token.", "expired_oauth_token", "")
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+func sessionToken(r *http.Request) string {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return token
+	}
+	return r.Header.Get("OAUTH-TOKEN")
+}
+
+func (s *Server) createSession(w http.ResponseWriter, r *http.Request) {
+	req := mageai.CreateSessionRequest{}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	auth := s.userAuthentication
+	if auth == nil {
+		writeBadRequest(w, "User authentication is not enabled.")
+		return
+	}
+
+	username := req.Session.Username
+	if username == "" {
+		username = req.Session.Email
+	}
+
+	if username != auth.username || req.Session.Password != auth.password {
+		writeError(w, http.StatusUnauthorized, "Email/username and/or password invalid.", "invalid_credentials", "")
+		return
+	}
+
+	token := newToken()
+	expiresAt := time.Now().Add(auth.sessionTTL)
+	auth.sessions[token] = expiresAt
+	auth.created++
+	writeJSON(w, map[string]any{
+		"session": mageai.Session{
+			Expires: expiresAt.UTC().Format(time.RFC3339Nano),
+			Token:   token,
+		},
+	})
+}
@@ -0,0 +1,160 @@
+package mageaitest
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+)
+
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SetBlockVariable stores an output variable of a block, as if the block was
+// executed in Mage AI.
+func (s *Server) SetBlockVariable(pipelineUUID, blockUUID string, variable mageai.Variable) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.blockVariables[pipelineUUID] == nil {
+		s.blockVariables[pipelineUUID] = map[string][]mageai.Variable{}
+	}
+	s.blockVariables[pipelineUUID][blockUUID] = append(s.blockVariables[pipelineUUID][blockUUID], variable)
+}
+
+// DeleteVariable removes a global variable as if it was deleted in the Mage AI
+// UI.
+func (s *Server) DeleteVariable(pipelineUUID, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.globalVariables[pipelineUUID], name)
+}
+
+// deleteVariables removes the variables of a deleted pipeline. s.mu must be
+// held.
+func (s *Server) deleteVariables(pipelineUUID string) {
+	delete(s.globalVariables, pipelineUUID)
+	delete(s.blockVariables, pipelineUUID)
+}
+
+// pipelineVariables returns the variables of the pipeline grouped by block,
+// starting with the global variables. s.mu must be held.
+func (s *Server) pipelineVariables(pipelineUUID string) []mageai.BlockVariables {
+	globalVariables := s.globalVariables[pipelineUUID]
+	variables := []mageai.BlockVariables{
+		newBlockVariables(pipelineUUID, mageai.GlobalVariablesBlockUUID, []mageai.Variable{}),
+	}
+	for _, name := range slices.Sorted(maps.Keys(globalVariables)) {
+		variables[0].Variables = append(variables[0].Variables, mageai.Variable{
+			Type:  "global",
+			UUID:  name,
+			Value: globalVariables[name],
+		})
+	}
+
+	blockVariables := s.blockVariables[pipelineUUID]
+	for _, blockUUID := range slices.Sorted(maps.Keys(blockVariables)) {
+		variables = append(variables, newBlockVariables(pipelineUUID, blockUUID, blockVariables[blockUUID]))
+	}
+	return variables
+}
+
+func newBlockVariables(pipelineUUID, blockUUID string, variables []mageai.Variable) mageai.BlockVariables {
+	blockVariables := mageai.BlockVariables{Variables: variables}
+	blockVariables.Block.UUID = blockUUID
+	blockVariables.Pipeline.UUID = pipelineUUID
+	return blockVariables
+}
+
+func (s *Server) readVariables(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipeline, ok := s.lookupPipeline(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, map[string]any{"variables": s.pipelineVariables(pipeline.UUID)})
+}
+
+func (s *Server) createVariable(w http.ResponseWriter, r *http.Request) {
+	req := mageai.CreateVariableRequest{}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipeline, ok := s.lookupPipeline(w, r)
+	if !ok {
+		return
+	}
+
+	if !identifierRegexp.MatchString(req.Variable.Name) {
+		writeBadRequest(w, fmt.Sprintf("Invalid variable name syntax for variable name %s.", req.Variable.Name))
+		return
+	}
+
+	if req.Variable.Value == nil {
+		writeBadRequest(w, fmt.Sprintf("Value is empty for variable name %s.", req.Variable.Name))
+		return
+	}
+
+	if s.globalVariables[pipeline.UUID] == nil {
+		s.globalVariables[pipeline.UUID] = map[string]any{}
+	}
+	s.globalVariables[pipeline.UUID][req.Variable.Name] = req.Variable.Value
+	writeJSON(w, map[string]any{"variable": s.pipelineVariables(pipeline.UUID)[0]})
+}
+
+func (s *Server) updateVariable(w http.ResponseWriter, r *http.Request) {
+	req := mageai.UpdateVariableRequest{}
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipeline, ok := s.lookupPipeline(w, r)
+	if !ok {
+		return
+	}
+
+	name := r.PathValue("variable")
+	if _, ok := s.globalVariables[pipeline.UUID][name]; !ok {
+		writeNotFound(w, fmt.Sprintf("Variable %s does not exist in pipeline %s.", name, pipeline.UUID))
+		return
+	}
+
+	if req.Variable.Value == nil {
+		writeBadRequest(w, fmt.Sprintf("Value is empty for variable name %s.", name))
+		return
+	}
+
+	s.globalVariables[pipeline.UUID][name] = req.Variable.Value
+	writeJSON(w, map[string]any{"variable": s.pipelineVariables(pipeline.UUID)[0]})
+}
+
+func (s *Server) deleteVariable(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pipeline, ok := s.lookupPipeline(w, r)
+	if !ok {
+		return
+	}
+
+	name := r.PathValue("variable")
+	if _, ok := s.globalVariables[pipeline.UUID][name]; !ok {
+		writeNotFound(w, fmt.Sprintf("Variable %s does not exist in pipeline %s.", name, pipeline.UUID))
+		return
+	}
+
+	delete(s.globalVariables[pipeline.UUID], name)
+	writeJSON(w, map[string]any{"variable": s.pipelineVariables(pipeline.UUID)[0]})
+}
@@ -1,10 +1,11 @@
 package mageai
 
 type errorResponse struct {
-	Error struct {
+	Error *struct {
 		Code      int    `json:"code"`
 		Exception string `json:"exception"`
 		Message   string `json:"message"`
+		Type      string `json:"type"`
 	} `json:"error"`
 }
 
@@ -17,19 +18,20 @@ type pipelinesResponse struct {
 }
 
 type Pipeline struct {
-	Blocks                   []Block     `json:"blocks"`
-	CacheBlockOutputInMemory bool        `json:"cache_block_output_in_memory"`
-	CreatedAt                string      `json:"created_at"`
-	Description              string      `json:"description"`
-	ExecutorCount            int32       `json:"executor_count"`
-	Name                     string      `json:"name"`
-	RetryConfig              RetryConfig `json:"retry_config"`
-	RunPipelineInOneProcess  bool        `json:"run_pipeline_in_one_process"`
-	Tags                     []string    `json:"tags"`
-	Type                     string      `json:"type"`
-	UUID                     string      `json:"uuid"`
-	UpdatedAt                string      `json:"updated_at"`
-	VariablesDir             string      `json:"variables_dir"`
+	Blocks                   []Block            `json:"blocks"`
+	CacheBlockOutputInMemory bool               `json:"cache_block_output_in_memory"`
+	CreatedAt                string             `json:"created_at"`
+	Description              string             `json:"description"`
+	ExecutorCount            int32              `json:"executor_count"`
+	Name                     string             `json:"name"`
+	RetryConfig              RetryConfig        `json:"retry_config"`
+	RunPipelineInOneProcess  bool               `json:"run_pipeline_in_one_process"`
+	Schedules                []PipelineSchedule `json:"schedules"`
+	Tags                     []string           `json:"tags"`
+	Type                     string             `json:"type"`
+	UUID                     string             `json:"uuid"`
+	UpdatedAt                string             `json:"updated_at"`
+	VariablesDir             string             `json:"variables_dir"`
 }
 
 type Block struct {
@@ -69,3 +71,68 @@ type RetryConfig struct {
 	MaxDelay           int32 `json:"max_delay"`
 	Retries            int32 `json:"retries"`
 }
+
+type PipelineSchedule struct {
+	CreatedAt        string                   `json:"created_at"`
+	Description      string                   `json:"description"`
+	ID               int64                    `json:"id"`
+	Name             string                   `json:"name"`
+	PipelineUUID     string                   `json:"pipeline_uuid"`
+	ScheduleInterval string                   `json:"schedule_interval"`
+	ScheduleType     string                   `json:"schedule_type"`
+	Settings         PipelineScheduleSettings `json:"settings"`
+	Sla              int64                    `json:"sla"`
+	StartTime        string                   `json:"start_time"`
+	Status           string                   `json:"status"`
+	Token            string                   `json:"token"`
+	UpdatedAt        string                   `json:"updated_at"`
+	Variables        map[string]any           `json:"variables"`
+}
+
+type PipelineScheduleSettings struct {
+	AllowBlocksToFail        bool    `json:"allow_blocks_to_fail"`
+	CreateInitialPipelineRun bool    `json:"create_initial_pipeline_run"`
+	SkipIfPreviousRunning    bool    `json:"skip_if_previous_running"`
+	Timeout                  *int64  `json:"timeout"`
+	TimeoutStatus            *string `json:"timeout_status"`
+}
+
+type BlockVariables struct {
+	Block struct {
+		UUID string `json:"uuid"`
+	} `json:"block"`
+	Pipeline struct {
+		UUID string `json:"uuid"`
+	} `json:"pipeline"`
+	Variables []Variable `json:"variables"`
+}
+
+type Variable struct {
+	Type  string `json:"type"`
+	UUID  string `json:"uuid"`
+	Value any    `json:"value"`
+}
+
+type Secret struct {
+	Name string `json:"name"`
+}
+
+type FileContent struct {
+	Content string `json:"content"`
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+}
+
+type PipelineRun struct {
+	CompletedAt          string         `json:"completed_at"`
+	CreatedAt            string         `json:"created_at"`
+	ExecutionDate        string         `json:"execution_date"`
+	ID                   int64          `json:"id"`
+	PipelineScheduleID   int64          `json:"pipeline_schedule_id"`
+	PipelineScheduleName string         `json:"pipeline_schedule_name"`
+	PipelineUUID         string         `json:"pipeline_uuid"`
+	StartedAt            string         `json:"started_at"`
+	Status               string         `json:"status"`
+	UpdatedAt            string         `json:"updated_at"`
+	Variables            map[string]any `json:"variables"`
+}
@@ -1,32 +1,45 @@
 package mageai
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
+	"net/url"
 	"path"
+	"regexp"
+	"slices"
 )
 
 const (
-	PipelinesAPIPath                     = "pipelines"
-	integrationPipelineType PipelineType = "integration"
-	pysparkPipelineType     PipelineType = "pyspark"
-	pythonPipelineType      PipelineType = "python"
-	streamingPipelineType   PipelineType = "streaming"
+	PipelinesAPIPath                         = "pipelines"
+	integrationPipelineType   PipelineType   = "integration"
+	pysparkPipelineType       PipelineType   = "pyspark"
+	pythonPipelineType        PipelineType   = "python"
+	streamingPipelineType     PipelineType   = "streaming"
+	ActivePipelineStatus      PipelineStatus = "active"
+	InactivePipelineStatus    PipelineStatus = "inactive"
+	NoSchedulesPipelineStatus PipelineStatus = "no_schedules"
 )
 
 type PipelineAPI interface {
 	CreatePipeline(ctx context.Context, pipelineParams *CreatePipelineRequest) (*pipelineResponse, error)
 	DeletePipeline(ctx context.Context, uuid *string) error
 	ReadPipeline(ctx context.Context, uuid *string) (*pipelineResponse, error)
-	ReadPipelines(ctx context.Context) (*pipelinesResponse, error)
+	ReadPipelines(ctx context.Context, filter *PipelinesFilter) (*pipelinesResponse, error)
 	UpdatePipeline(ctx context.Context, uuid *string, pipelineParams *UpdatePipelineRequest) (*pipelineResponse, error)
 }
 
 type PipelineType string
 
+// PipelineStatus is the status of a pipeline, derived by Mage AI from the
+// status of its triggers (pipeline schedules).
+type PipelineStatus string
+
+var pipelineResource = restResource[Pipeline]{
+	name:   "pipeline",
+	plural: "pipelines",
+	id:     func(pipeline *Pipeline) string { return pipeline.UUID },
+}
+
 type CreatePipelineRequest struct {
 	Pipeline PipelineRequest `json:"pipeline"`
 }
@@ -35,9 +48,33 @@ type UpdatePipelineRequest struct {
 	Pipeline PipelineRequest `json:"pipeline"`
 }
 
+// PipelineRequest holds the pipeline fields to create or update. Nil fields
+// are not sent, so Mage AI keeps their current value.
 type PipelineRequest struct {
-	Name string       `json:"name"`
-	Type PipelineType `json:"type"`
+	CacheBlockOutputInMemory *bool        `json:"cache_block_output_in_memory,omitempty"`
+	Description              *string      `json:"description,omitempty"`
+	ExecutorCount            *int32       `json:"executor_count,omitempty"`
+	Name                     string       `json:"name"`
+	RetryConfig              *RetryConfig `json:"retry_config,omitempty"`
+	RunPipelineInOneProcess  *bool        `json:"run_pipeline_in_one_process,omitempty"`
+	Tags                     *[]string    `json:"tags,omitempty"`
+	Type                     PipelineType `json:"type"`
+}
+
+// PipelinesFilter narrows down the pipelines returned by ReadPipelines. Zero
+// fields do not filter. The filters are sent to Mage AI, and applied again to
+// the pipelines it returns since some versions of Mage AI ignore some of them.
+type PipelinesFilter struct {
+	// AllTags requires the pipelines to have all the Tags instead of any of
+	// them.
+	AllTags   bool
+	NameRegex *regexp.Regexp
+	// OmitContent removes the content of the blocks from the pipelines, to
+	// reduce the size of the response.
+	OmitContent bool
+	Status      PipelineStatus
+	Tags        []string
+	Type        PipelineType
 }
 
 func (pt PipelineType) IsValid() bool {
@@ -48,130 +85,145 @@ func (pt PipelineType) IsValid() bool {
 	return false
 }
 
-func (c *client) CreatePipeline(ctx context.Context, pipelineRequest *CreatePipelineRequest) (*pipelineResponse, error) {
-	if !pipelineRequest.Pipeline.Type.IsValid() {
-		return nil, fmt.Errorf("invalid pipeline type: %s", pipelineRequest.Pipeline.Type)
+func (ps PipelineStatus) IsValid() bool {
+	switch ps {
+	case ActivePipelineStatus, InactivePipelineStatus, NoSchedulesPipelineStatus:
+		return true
 	}
+	return false
+}
 
-	reqBody, err := json.Marshal(pipelineRequest)
-	if err != nil {
-		return nil, err
+// PipelineStatusOf returns the status of a pipeline from its schedules, as
+// Mage AI does: active when one of its schedules is active, inactive when
+// none is, and no_schedules when it has no schedule.
+func PipelineStatusOf(schedules []PipelineSchedule) PipelineStatus {
+	if len(schedules) == 0 {
+		return NoSchedulesPipelineStatus
 	}
 
-	respBody, err := c.makeAPICall(http.MethodPost, PipelinesAPIPath, bytes.NewBuffer(reqBody))
-	if err != nil {
-		return nil, err
+	if slices.ContainsFunc(schedules, func(schedule PipelineSchedule) bool { return ScheduleStatus(schedule.Status) == activeScheduleStatus }) {
+		return ActivePipelineStatus
 	}
+	return InactivePipelineStatus
+}
 
-	createPipelineResponse := pipelineResponse{}
-	err = json.Unmarshal(respBody, &createPipelineResponse)
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
+func (f *PipelinesFilter) query() url.Values {
+	query := url.Values{}
+	if f == nil {
+		return query
 	}
 
-	if createPipelineResponse.Pipeline.UUID == "" {
-		errRes := errorResponse{}
-		err = json.Unmarshal(respBody, &errRes)
-		if err != nil {
-			return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
-		}
-		return nil, fmt.Errorf("error creating pipeline: %s, Status code: %d", errRes.Error.Exception, errRes.Error.Code)
+	if f.OmitContent {
+		query.Set("includes_content", "false")
 	}
-	return &createPipelineResponse, nil
-}
 
-func (c *client) DeletePipeline(ctx context.Context, uuid *string) error {
-	respBody, err := c.makeAPICall(http.MethodDelete, path.Join(PipelinesAPIPath, *uuid), nil)
-	if err != nil {
-		return err
+	if f.Status != "" {
+		query.Set("include_schedules", "1")
+		query.Add("status[]", string(f.Status))
 	}
 
-	deletePipelineResponse := pipelineResponse{}
-	err = json.Unmarshal(respBody, &deletePipelineResponse)
-	if err != nil {
-		return fmt.Errorf("error unmarshalling JSON: %w", err)
+	for _, tag := range f.Tags {
+		query.Add("tag[]", tag)
 	}
 
-	if deletePipelineResponse.Pipeline.UUID == "" {
-		errRes := errorResponse{}
-		err = json.Unmarshal(respBody, &errRes)
-		if err != nil {
-			return fmt.Errorf("error unmarshalling JSON: %w", err)
-		}
-		return fmt.Errorf("error deleting pipeline: %s, Status code: %d", errRes.Error.Exception, errRes.Error.Code)
+	if f.Type != "" {
+		query.Add("type[]", string(f.Type))
 	}
-	return nil
+	return query
 }
 
-func (c *client) ReadPipeline(ctx context.Context, uuid *string) (*pipelineResponse, error) {
-	readPipelineResponse := pipelineResponse{}
-	body, err := c.makeAPICall(http.MethodGet, path.Join(PipelinesAPIPath, *uuid), nil)
-	if err != nil {
-		return nil, err
+// matches reports whether the pipeline matches the filter. A pipeline returned
+// without schedules has the no_schedules status.
+func (f *PipelinesFilter) matches(pipeline Pipeline) bool {
+	if f == nil {
+		return true
 	}
 
-	err = json.Unmarshal(body, &readPipelineResponse)
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
+	if f.NameRegex != nil && !f.NameRegex.MatchString(pipeline.Name) {
+		return false
 	}
 
-	if readPipelineResponse.Pipeline.UUID == "" {
-		errRes := errorResponse{}
-		err = json.Unmarshal(body, &errRes)
-		if err != nil {
-			return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
+	if f.Status != "" && PipelineStatusOf(pipeline.Schedules) != f.Status {
+		return false
+	}
+
+	if len(f.Tags) > 0 {
+		hasTag := func(tag string) bool { return slices.Contains(pipeline.Tags, tag) }
+		if f.AllTags && !allOf(f.Tags, hasTag) || !f.AllTags && !slices.ContainsFunc(f.Tags, hasTag) {
+			return false
 		}
-		return nil, fmt.Errorf("error getting pipeline: %s, Status code: %d", errRes.Error.Exception, errRes.Error.Code)
 	}
-	return &readPipelineResponse, nil
-}
 
-func (c *client) ReadPipelines(ctx context.Context) (*pipelinesResponse, error) {
-	readPipelinesResponse := pipelinesResponse{}
-	body, err := c.makeAPICall(http.MethodGet, PipelinesAPIPath, nil)
-	if err != nil {
-		return nil, err
+	if f.Type != "" && pipeline.Type != string(f.Type) {
+		return false
 	}
+	return true
+}
 
-	err = json.Unmarshal(body, &readPipelinesResponse)
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
+func allOf[T any](values []T, predicate func(T) bool) bool {
+	for _, value := range values {
+		if !predicate(value) {
+			return false
+		}
 	}
-	return &readPipelinesResponse, nil
+	return true
 }
 
-func (c *client) UpdatePipeline(ctx context.Context, uuid *string, pipelineRequest *UpdatePipelineRequest) (*pipelineResponse, error) {
+func (c *client) CreatePipeline(ctx context.Context, pipelineRequest *CreatePipelineRequest) (*pipelineResponse, error) {
 	if !pipelineRequest.Pipeline.Type.IsValid() {
 		return nil, fmt.Errorf("invalid pipeline type: %s", pipelineRequest.Pipeline.Type)
 	}
 
-	reqBody, err := json.Marshal(pipelineRequest)
+	pipeline, err := pipelineResource.create(ctx, c, PipelinesAPIPath, pipelineRequest.Pipeline)
 	if err != nil {
 		return nil, err
 	}
+	return &pipelineResponse{Pipeline: *pipeline}, nil
+}
 
-	fmt.Println(string(reqBody))
+func (c *client) DeletePipeline(ctx context.Context, uuid *string) error {
+	_, err := pipelineResource.delete(ctx, c, path.Join(PipelinesAPIPath, *uuid))
+	return err
+}
 
-	respBody, err := c.makeAPICall(http.MethodPut, path.Join(PipelinesAPIPath, *uuid), bytes.NewBuffer(reqBody))
+func (c *client) ReadPipeline(ctx context.Context, uuid *string) (*pipelineResponse, error) {
+	pipeline, err := pipelineResource.read(ctx, c, path.Join(PipelinesAPIPath, *uuid), nil)
 	if err != nil {
 		return nil, err
 	}
+	return &pipelineResponse{Pipeline: *pipeline}, nil
+}
 
-	fmt.Printf("respBody: %s\n", string(respBody))
-
-	updatePipelineResponse := pipelineResponse{}
-	err = json.Unmarshal(respBody, &updatePipelineResponse)
+func (c *client) ReadPipelines(ctx context.Context, filter *PipelinesFilter) (*pipelinesResponse, error) {
+	pipelines, err := pipelineResource.list(ctx, c, PipelinesAPIPath, filter.query())
 	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
+		return nil, err
 	}
 
-	if updatePipelineResponse.Pipeline.UUID == "" {
-		errRes := errorResponse{}
-		err = json.Unmarshal(respBody, &errRes)
-		if err != nil {
-			return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
+	filteredPipelines := []Pipeline{}
+	for _, pipeline := range pipelines {
+		if !filter.matches(pipeline) {
+			continue
+		}
+
+		if filter != nil && filter.OmitContent {
+			for i := range pipeline.Blocks {
+				pipeline.Blocks[i].Content = ""
+			}
 		}
-		return nil, fmt.Errorf("error updating pipeline: %s, Status code: %d", errRes.Error.Exception, errRes.Error.Code)
+		filteredPipelines = append(filteredPipelines, pipeline)
+	}
+	return &pipelinesResponse{Pipelines: filteredPipelines}, nil
+}
+
+func (c *client) UpdatePipeline(ctx context.Context, uuid *string, pipelineRequest *UpdatePipelineRequest) (*pipelineResponse, error) {
+	if !pipelineRequest.Pipeline.Type.IsValid() {
+		return nil, fmt.Errorf("invalid pipeline type: %s", pipelineRequest.Pipeline.Type)
+	}
+
+	pipeline, err := pipelineResource.update(ctx, c, path.Join(PipelinesAPIPath, *uuid), pipelineRequest.Pipeline)
+	if err != nil {
+		return nil, err
 	}
-	return &updatePipelineResponse, nil
+	return &pipelineResponse{Pipeline: *pipeline}, nil
 }
@@ -0,0 +1,134 @@
+package mageai
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+)
+
+const (
+	PipelineRunsAPIPath                          = "pipeline_runs"
+	CancelledPipelineRunStatus PipelineRunStatus = "cancelled"
+	CompletedPipelineRunStatus PipelineRunStatus = "completed"
+	FailedPipelineRunStatus    PipelineRunStatus = "failed"
+	InitialPipelineRunStatus   PipelineRunStatus = "initial"
+	RunningPipelineRunStatus   PipelineRunStatus = "running"
+)
+
+type PipelineRunAPI interface {
+	CreatePipelineRun(ctx context.Context, pipelineScheduleID *int64, pipelineRunRequest *CreatePipelineRunRequest) (*pipelineRunResponse, error)
+	ReadPipelineRun(ctx context.Context, id *int64) (*pipelineRunResponse, error)
+	ReadPipelineRuns(ctx context.Context, filter *PipelineRunsFilter) (*pipelineRunsResponse, error)
+}
+
+type PipelineRunStatus string
+
+var pipelineRunResource = restResource[PipelineRun]{
+	name:   "pipeline_run",
+	plural: "pipeline_runs",
+	id: func(pipelineRun *PipelineRun) string {
+		if pipelineRun.ID == 0 {
+			return ""
+		}
+		return strconv.FormatInt(pipelineRun.ID, 10)
+	},
+}
+
+type pipelineRunResponse struct {
+	PipelineRun PipelineRun `json:"pipeline_run"`
+}
+
+type pipelineRunsResponse struct {
+	PipelineRuns []PipelineRun `json:"pipeline_runs"`
+}
+
+type CreatePipelineRunRequest struct {
+	PipelineRun PipelineRunRequest `json:"pipeline_run"`
+}
+
+type PipelineRunRequest struct {
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+// PipelineRunsFilter narrows down the pipeline runs returned by
+// ReadPipelineRuns. Zero fields do not filter.
+type PipelineRunsFilter struct {
+	// CreatedAfter and CreatedBefore bound the creation time of the runs.
+	CreatedAfter       time.Time
+	CreatedBefore      time.Time
+	PipelineScheduleID int64
+	PipelineUUID       string
+	Status             PipelineRunStatus
+}
+
+func (prs PipelineRunStatus) IsValid() bool {
+	switch prs {
+	case CancelledPipelineRunStatus, CompletedPipelineRunStatus, FailedPipelineRunStatus, InitialPipelineRunStatus, RunningPipelineRunStatus:
+		return true
+	}
+	return false
+}
+
+// IsTerminal reports whether a pipeline run with the status is over.
+func (prs PipelineRunStatus) IsTerminal() bool {
+	switch prs {
+	case CancelledPipelineRunStatus, CompletedPipelineRunStatus, FailedPipelineRunStatus:
+		return true
+	}
+	return false
+}
+
+func (f *PipelineRunsFilter) query() url.Values {
+	query := url.Values{}
+	if f == nil {
+		return query
+	}
+
+	if !f.CreatedAfter.IsZero() {
+		query.Set("start_timestamp", strconv.FormatInt(f.CreatedAfter.Unix(), 10))
+	}
+
+	if !f.CreatedBefore.IsZero() {
+		query.Set("end_timestamp", strconv.FormatInt(f.CreatedBefore.Unix(), 10))
+	}
+
+	if f.PipelineScheduleID != 0 {
+		query.Set("pipeline_schedule_id", strconv.FormatInt(f.PipelineScheduleID, 10))
+	}
+
+	if f.PipelineUUID != "" {
+		query.Set("pipeline_uuid", f.PipelineUUID)
+	}
+
+	if f.Status != "" {
+		query.Set("status", string(f.Status))
+	}
+	return query
+}
+
+// CreatePipelineRun triggers a run of the pipeline of the pipeline schedule.
+func (c *client) CreatePipelineRun(ctx context.Context, pipelineScheduleID *int64, pipelineRunRequest *CreatePipelineRunRequest) (*pipelineRunResponse, error) {
+	pipelineRun, err := pipelineRunResource.create(ctx, c, path.Join(PipelineSchedulesAPIPath, strconv.FormatInt(*pipelineScheduleID, 10), PipelineRunsAPIPath), pipelineRunRequest.PipelineRun)
+	if err != nil {
+		return nil, err
+	}
+	return &pipelineRunResponse{PipelineRun: *pipelineRun}, nil
+}
+
+func (c *client) ReadPipelineRun(ctx context.Context, id *int64) (*pipelineRunResponse, error) {
+	pipelineRun, err := pipelineRunResource.read(ctx, c, path.Join(PipelineRunsAPIPath, strconv.FormatInt(*id, 10)), nil)
+	if err != nil {
+		return nil, err
+	}
+	return &pipelineRunResponse{PipelineRun: *pipelineRun}, nil
+}
+
+func (c *client) ReadPipelineRuns(ctx context.Context, filter *PipelineRunsFilter) (*pipelineRunsResponse, error) {
+	pipelineRuns, err := pipelineRunResource.list(ctx, c, PipelineRunsAPIPath, filter.query())
+	if err != nil {
+		return nil, err
+	}
+	return &pipelineRunsResponse{PipelineRuns: pipelineRuns}, nil
+}
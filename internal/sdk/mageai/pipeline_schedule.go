@@ -0,0 +1,131 @@
+package mageai
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+)
+
+const (
+	PipelineSchedulesAPIPath                = "pipeline_schedules"
+	activeScheduleStatus     ScheduleStatus = "active"
+	inactiveScheduleStatus   ScheduleStatus = "inactive"
+	apiScheduleType          ScheduleType   = "api"
+	eventScheduleType        ScheduleType   = "event"
+	timeScheduleType         ScheduleType   = "time"
+)
+
+type PipelineScheduleAPI interface {
+	CreatePipelineSchedule(ctx context.Context, pipelineUUID *string, pipelineScheduleRequest *CreatePipelineScheduleRequest) (*pipelineScheduleResponse, error)
+	DeletePipelineSchedule(ctx context.Context, id *int64) error
+	ReadPipelineSchedule(ctx context.Context, id *int64) (*pipelineScheduleResponse, error)
+	UpdatePipelineSchedule(ctx context.Context, id *int64, pipelineScheduleRequest *UpdatePipelineScheduleRequest) (*pipelineScheduleResponse, error)
+}
+
+type ScheduleStatus string
+
+var pipelineScheduleResource = restResource[PipelineSchedule]{
+	name:   "pipeline_schedule",
+	plural: "pipeline_schedules",
+	id: func(pipelineSchedule *PipelineSchedule) string {
+		if pipelineSchedule.ID == 0 {
+			return ""
+		}
+		return strconv.FormatInt(pipelineSchedule.ID, 10)
+	},
+}
+
+type ScheduleType string
+
+type pipelineScheduleResponse struct {
+	PipelineSchedule PipelineSchedule `json:"pipeline_schedule"`
+}
+
+type CreatePipelineScheduleRequest struct {
+	PipelineSchedule PipelineScheduleRequest `json:"pipeline_schedule"`
+}
+
+type UpdatePipelineScheduleRequest struct {
+	PipelineSchedule PipelineScheduleRequest `json:"pipeline_schedule"`
+}
+
+// PipelineScheduleRequest holds the pipeline schedule fields to create or
+// update. Nil fields are sent as null, so that Mage AI clears them.
+type PipelineScheduleRequest struct {
+	Description      string                   `json:"description"`
+	Name             string                   `json:"name"`
+	ScheduleInterval string                   `json:"schedule_interval,omitempty"`
+	ScheduleType     ScheduleType             `json:"schedule_type"`
+	Settings         PipelineScheduleSettings `json:"settings"`
+	Sla              *int64                   `json:"sla"`
+	StartTime        *string                  `json:"start_time"`
+	Status           ScheduleStatus           `json:"status"`
+	Variables        map[string]any           `json:"variables"`
+}
+
+func (r PipelineScheduleRequest) validate() error {
+	if !r.ScheduleType.IsValid() {
+		return fmt.Errorf("invalid schedule type: %s", r.ScheduleType)
+	}
+
+	if !r.Status.IsValid() {
+		return fmt.Errorf("invalid schedule status: %s", r.Status)
+	}
+	return nil
+}
+
+func (ss ScheduleStatus) IsValid() bool {
+	switch ss {
+	case activeScheduleStatus, inactiveScheduleStatus:
+		return true
+	}
+	return false
+}
+
+func (st ScheduleType) IsValid() bool {
+	switch st {
+	case apiScheduleType, eventScheduleType, timeScheduleType:
+		return true
+	}
+	return false
+}
+
+func (c *client) CreatePipelineSchedule(ctx context.Context, pipelineUUID *string, pipelineScheduleRequest *CreatePipelineScheduleRequest) (*pipelineScheduleResponse, error) {
+	err := pipelineScheduleRequest.PipelineSchedule.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	pipelineSchedule, err := pipelineScheduleResource.create(ctx, c, path.Join(PipelinesAPIPath, *pipelineUUID, PipelineSchedulesAPIPath), pipelineScheduleRequest.PipelineSchedule)
+	if err != nil {
+		return nil, err
+	}
+	return &pipelineScheduleResponse{PipelineSchedule: *pipelineSchedule}, nil
+}
+
+func (c *client) DeletePipelineSchedule(ctx context.Context, id *int64) error {
+	_, err := pipelineScheduleResource.delete(ctx, c, path.Join(PipelineSchedulesAPIPath, strconv.FormatInt(*id, 10)))
+	return err
+}
+
+func (c *client) ReadPipelineSchedule(ctx context.Context, id *int64) (*pipelineScheduleResponse, error) {
+	pipelineSchedule, err := pipelineScheduleResource.read(ctx, c, path.Join(PipelineSchedulesAPIPath, strconv.FormatInt(*id, 10)), nil)
+	if err != nil {
+		return nil, err
+	}
+	return &pipelineScheduleResponse{PipelineSchedule: *pipelineSchedule}, nil
+}
+
+func (c *client) UpdatePipelineSchedule(ctx context.Context, id *int64, pipelineScheduleRequest *UpdatePipelineScheduleRequest) (*pipelineScheduleResponse, error) {
+	err := pipelineScheduleRequest.PipelineSchedule.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	pipelineSchedule, err := pipelineScheduleResource.update(ctx, c, path.Join(PipelineSchedulesAPIPath, strconv.FormatInt(*id, 10)), pipelineScheduleRequest.PipelineSchedule)
+	if err != nil {
+		return nil, err
+	}
+	return &pipelineScheduleResponse{PipelineSchedule: *pipelineSchedule}, nil
+}
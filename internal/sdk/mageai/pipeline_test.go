@@ -0,0 +1,100 @@
+package mageai
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+// pipelinesTestResponse has pipelines of every status, as returned by a
+// version of Mage AI that ignores the filters.
+const pipelinesTestResponse = `{"pipelines": [
+  {"uuid": "etl", "name": "etl", "type": "python", "tags": ["daily", "sales"], "schedules": [{"status": "active"}], "blocks": [{"uuid": "load", "content": "print(1)"}]},
+  {"uuid": "etl_backfill", "name": "etl backfill", "type": "python", "tags": ["sales"], "schedules": [{"status": "inactive"}]},
+  {"uuid": "events", "name": "events", "type": "streaming", "tags": ["daily"], "schedules": []},
+  {"uuid": "reports", "name": "reports", "type": "python", "tags": []}
+]}`
+
+func TestReadPipelinesFilter(t *testing.T) {
+	testCases := map[string]struct {
+		filter        *PipelinesFilter
+		expectedURI   string
+		expectedUUIDs []string
+	}{
+		"none": {
+			expectedURI:   "/api/pipelines",
+			expectedUUIDs: []string{"etl", "etl_backfill", "events", "reports"},
+		},
+		"type": {
+			filter:        &PipelinesFilter{Type: "streaming"},
+			expectedURI:   "/api/pipelines?type%5B%5D=streaming",
+			expectedUUIDs: []string{"events"},
+		},
+		"any tags": {
+			filter:        &PipelinesFilter{Tags: []string{"daily", "sales"}},
+			expectedURI:   "/api/pipelines?tag%5B%5D=daily&tag%5B%5D=sales",
+			expectedUUIDs: []string{"etl", "etl_backfill", "events"},
+		},
+		"all tags": {
+			filter:        &PipelinesFilter{AllTags: true, Tags: []string{"daily", "sales"}},
+			expectedURI:   "/api/pipelines?tag%5B%5D=daily&tag%5B%5D=sales",
+			expectedUUIDs: []string{"etl"},
+		},
+		"name regex": {
+			filter:        &PipelinesFilter{NameRegex: regexp.MustCompile(`^etl\b`)},
+			expectedURI:   "/api/pipelines",
+			expectedUUIDs: []string{"etl", "etl_backfill"},
+		},
+		"status": {
+			filter:        &PipelinesFilter{Status: InactivePipelineStatus},
+			expectedURI:   "/api/pipelines?include_schedules=1&status%5B%5D=inactive",
+			expectedUUIDs: []string{"etl_backfill"},
+		},
+		"no schedules": {
+			filter:        &PipelinesFilter{Status: NoSchedulesPipelineStatus},
+			expectedURI:   "/api/pipelines?include_schedules=1&status%5B%5D=no_schedules",
+			expectedUUIDs: []string{"events", "reports"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c, received := newRESTTestServer(t, pipelinesTestResponse)
+
+			resp, err := c.ReadPipelines(context.Background(), testCase.filter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if received.uri != testCase.expectedURI {
+				t.Errorf("unexpected request URI: %s", received.uri)
+			}
+
+			uuids := []string{}
+			for _, pipeline := range resp.Pipelines {
+				uuids = append(uuids, pipeline.UUID)
+			}
+			if !slices.Equal(uuids, testCase.expectedUUIDs) {
+				t.Errorf("expected pipelines %v, got %v", testCase.expectedUUIDs, uuids)
+			}
+		})
+	}
+}
+
+func TestReadPipelinesOmitContent(t *testing.T) {
+	c, received := newRESTTestServer(t, pipelinesTestResponse)
+
+	resp, err := c.ReadPipelines(context.Background(), &PipelinesFilter{OmitContent: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if received.uri != "/api/pipelines?includes_content=false" {
+		t.Errorf("unexpected request URI: %s", received.uri)
+	}
+
+	if blocks := resp.Pipelines[0].Blocks; len(blocks) != 1 || blocks[0].UUID != "load" || blocks[0].Content != "" {
+		t.Errorf("expected the block without its content, got %+v", blocks)
+	}
+}
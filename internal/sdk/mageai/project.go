@@ -0,0 +1,132 @@
+package mageai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sync"
+)
+
+const (
+	ProjectsAPIPath = "projects"
+)
+
+type UpdateProjectRequest struct {
+	Project ProjectRequest `json:"project"`
+}
+
+type ProjectRequest struct {
+	ActivateProject string `json:"activate_project"`
+}
+
+// projects tracks the project activated by the clients sharing a session.
+// Mage AI sends the requests to the project last activated, so a project is
+// only activated once the requests sent to the previous one finished, while
+// the requests sent to the same project run concurrently.
+type projects struct {
+	mu         sync.Mutex
+	active     string
+	activating bool
+	inFlight   int
+	// changed is closed, and replaced, when the last request in flight is done
+	// or an activation ends, to wake up the requests waiting for either.
+	changed chan struct{}
+}
+
+func newProjects() *projects {
+	return &projects{changed: make(chan struct{})}
+}
+
+// notify wakes up the requests waiting for a change. p.mu must be held.
+func (p *projects) notify() {
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+// enterProject activates the project of the client, if needed, before sending
+// a request. Every successful call must be followed by a call to leaveProject
+// once the request is done. Clients without a project send their requests to
+// whichever project is active. Waiting for the requests sent to another
+// project, or for the activation of a project, ends when ctx is done.
+func (c *client) enterProject(ctx context.Context, httpMethod, path string) error {
+	if c.config.Project == "" {
+		return nil
+	}
+
+	p := c.projects
+	p.mu.Lock()
+	for {
+		if !p.activating && p.active == c.config.Project {
+			p.inFlight++
+			p.mu.Unlock()
+			return nil
+		}
+
+		if !p.activating && p.inFlight == 0 {
+			break
+		}
+
+		changed := p.changed
+		p.mu.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return &ContextError{Method: httpMethod, Path: path, Err: ctx.Err()}
+		}
+		p.mu.Lock()
+	}
+
+	// The project is activated without holding the lock, the other requests
+	// wait for the activation to end. The active project is unknown when the
+	// activation fails, as Mage AI may have activated it before the request
+	// failed.
+	p.activating = true
+	p.active = ""
+	p.mu.Unlock()
+
+	err := c.activateProject(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.activating = false
+	if err == nil {
+		p.active = c.config.Project
+		p.inFlight++
+	}
+	p.notify()
+	return err
+}
+
+func (c *client) leaveProject() {
+	if c.config.Project == "" {
+		return
+	}
+
+	p := c.projects
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.inFlight--
+	if p.inFlight == 0 {
+		p.notify()
+	}
+}
+
+func (c *client) activateProject(ctx context.Context) error {
+	reqBody, err := json.Marshal(UpdateProjectRequest{
+		Project: ProjectRequest{ActivateProject: c.config.Project},
+	})
+	if err != nil {
+		return fmt.Errorf("error marshalling JSON: %w", err)
+	}
+
+	_, err = c.sendAPICall(ctx, http.MethodPut, path.Join(ProjectsAPIPath, url.PathEscape(c.config.Project)), reqBody)
+	if err != nil {
+		return fmt.Errorf("error activating project %s: %w", c.config.Project, err)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package mageai_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func newProjectTestClient(t *testing.T, server *mageaitest.Server, project string) mageai.Client {
+	t.Helper()
+
+	client, err := mageai.New(&mageai.ClientConfig{
+		ApiKey:  mageaitest.APIKey,
+		Host:    server.URL,
+		Project: project,
+	})
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	t.Cleanup(client.Close)
+	return client
+}
+
+func createPipeline(t *testing.T, client mageai.Client, name string) {
+	t.Helper()
+
+	_, err := client.PipelineAPI().CreatePipeline(context.Background(), &mageai.CreatePipelineRequest{
+		Pipeline: mageai.PipelineRequest{Name: name, Type: mageai.PipelineType("python")},
+	})
+	if err != nil {
+		t.Errorf("creating pipeline %s: %v", name, err)
+	}
+}
+
+func TestProjectActivated(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.AddProjects("sales", "marketing")
+	client := newProjectTestClient(t, server, "sales")
+
+	createPipeline(t, client, "orders")
+	createPipeline(t, client.WithProject("marketing"), "campaigns")
+	readPipelines(t, client)
+
+	if client.Project() != "sales" || client.WithProject("").Project() != "sales" {
+		t.Errorf("expected the client to keep its project, got %q", client.Project())
+	}
+
+	for project, uuid := range map[string]string{"sales": "orders", "marketing": "campaigns"} {
+		if _, ok := server.ProjectPipeline(project, uuid); !ok {
+			t.Errorf("expected pipeline %s in project %s", uuid, project)
+		}
+	}
+
+	if _, ok := server.ProjectPipeline("", "orders"); ok {
+		t.Error("expected no pipeline in the root project")
+	}
+
+	if activations := server.ProjectActivations(); activations != 3 {
+		t.Errorf("expected the projects to be activated 3 times, got %d", activations)
+	}
+}
+
+func TestProjectNotActivatedWithoutProject(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.AddProjects("sales")
+	client := newProjectTestClient(t, server, "")
+
+	createPipeline(t, client, "orders")
+
+	if _, ok := server.ProjectPipeline("", "orders"); !ok {
+		t.Error("expected the pipeline in the active project")
+	}
+
+	if activations := server.ProjectActivations(); activations != 0 {
+		t.Errorf("expected no project to be activated, got %d activations", activations)
+	}
+}
+
+func TestProjectUnknown(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.AddProjects("sales")
+	client := newProjectTestClient(t, server, "unknown")
+
+	_, err := client.PipelineAPI().ReadPipelines(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "error activating project unknown") {
+		t.Errorf("expected an error activating the project, got %v", err)
+	}
+}
+
+func TestProjectConcurrentRequests(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.AddProjects("sales", "marketing")
+	client := newProjectTestClient(t, server, "sales")
+	projects := []string{"sales", "marketing"}
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			project := projects[i%len(projects)]
+			createPipeline(t, client.WithProject(project), fmt.Sprintf("%s_%d", project, i))
+		}()
+	}
+	wg.Wait()
+
+	for i := range 20 {
+		project := projects[i%len(projects)]
+		uuid := fmt.Sprintf("%s_%d", project, i)
+		if _, ok := server.ProjectPipeline(project, uuid); !ok {
+			t.Errorf("expected pipeline %s in project %s", uuid, project)
+		}
+	}
+}
+
+func TestProjectWaitEndsWithContext(t *testing.T) {
+	testCases := map[string]string{
+		"request in flight":    "GET /api/pipelines",
+		"activation in flight": "PUT /api/projects/sales",
+	}
+
+	for name, blockedRequest := range testCases {
+		t.Run(name, func(t *testing.T) {
+			received := make(chan struct{})
+			release := make(chan struct{})
+			var blockOnce sync.Once
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method+" "+r.URL.Path == blockedRequest {
+					blockOnce.Do(func() {
+						close(received)
+						<-release
+					})
+				}
+				_, _ = w.Write([]byte(`{"pipelines": [], "project": {}}`))
+			}))
+			defer server.Close()
+			client := newTestClient(t, server.URL)
+
+			done := make(chan error)
+			go func() {
+				_, err := client.WithProject("sales").PipelineAPI().ReadPipelines(context.Background(), nil)
+				done <- err
+			}()
+			<-received
+
+			// The request to another project waits for the blocked request,
+			// until its deadline
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			_, err := client.WithProject("marketing").PipelineAPI().ReadPipelines(ctx, nil)
+			if !mageai.IsTimeout(err) {
+				t.Errorf("expected a timeout error, got %v", err)
+			}
+
+			close(release)
+			if err := <-done; err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if _, err := client.WithProject("marketing").PipelineAPI().ReadPipelines(context.Background(), nil); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package mageai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// doJSON sends a request to the Mage AI API, with reqBody encoded as JSON when
+// it is not nil, and decodes the response into a Resp. The query parameters,
+// if any, are added to the path.
+func doJSON[Req, Resp any](ctx context.Context, c *client, httpMethod, apiPath string, query url.Values, reqBody *Req) (*Resp, error) {
+	var body io.Reader
+	if reqBody != nil {
+		encoded, err := json.Marshal(reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling JSON: %w", err)
+		}
+		body = bytes.NewBuffer(encoded)
+	}
+
+	if len(query) > 0 {
+		apiPath += "?" + query.Encode()
+	}
+
+	respBody, err := c.makeAPICall(ctx, httpMethod, apiPath, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(Resp)
+	if len(respBody) == 0 {
+		return resp, nil
+	}
+
+	err = json.Unmarshal(respBody, resp)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
+	}
+	return resp, nil
+}
+
+// restResource describes a resource of the Mage AI API. Mage AI wraps a
+// single object in request and response envelopes keyed by the name of the
+// resource, e.g. {"pipeline": {...}}, and a list of objects in an envelope
+// keyed by its plural, e.g. {"pipelines": [...]}.
+type restResource[T any] struct {
+	name   string
+	plural string
+	// id returns the identifier of an object, an empty one meaning that Mage
+	// AI answered with an unexpected response.
+	id func(*T) string
+}
+
+func (r restResource[T]) create(ctx context.Context, c *client, apiPath string, object any) (*T, error) {
+	return r.do(ctx, c, "creating", http.MethodPost, apiPath, nil, object)
+}
+
+func (r restResource[T]) delete(ctx context.Context, c *client, apiPath string) (*T, error) {
+	return r.do(ctx, c, "deleting", http.MethodDelete, apiPath, nil, nil)
+}
+
+func (r restResource[T]) read(ctx context.Context, c *client, apiPath string, query url.Values) (*T, error) {
+	return r.do(ctx, c, "getting", http.MethodGet, apiPath, query, nil)
+}
+
+func (r restResource[T]) update(ctx context.Context, c *client, apiPath string, object any) (*T, error) {
+	return r.do(ctx, c, "updating", http.MethodPut, apiPath, nil, object)
+}
+
+// list returns the objects listed at the path. It fails when the response has
+// no list of the resource, an empty list being valid.
+func (r restResource[T]) list(ctx context.Context, c *client, apiPath string, query url.Values) ([]T, error) {
+	respBody, err := doJSON[any, json.RawMessage](ctx, c, http.MethodGet, apiPath, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []T
+	ok, err := decodeEnvelope(*respBody, r.plural, &objects)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("error getting %s: unexpected response: %s", displayName(r.plural), *respBody)
+	}
+
+	if objects == nil {
+		objects = []T{}
+	}
+	return objects, nil
+}
+
+// do sends the object, if any, wrapped in the envelope of the resource and
+// returns the object of the response.
+func (r restResource[T]) do(ctx context.Context, c *client, action, httpMethod, apiPath string, query url.Values, object any) (*T, error) {
+	var reqBody *map[string]any
+	if object != nil {
+		reqBody = &map[string]any{r.name: object}
+	}
+
+	respBody, err := doJSON[map[string]any, json.RawMessage](ctx, c, httpMethod, apiPath, query, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(T)
+	ok, err := decodeEnvelope(*respBody, r.name, result)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok || r.id(result) == "" {
+		return nil, fmt.Errorf("error %s %s: unexpected response: %s", action, displayName(r.name), *respBody)
+	}
+	return result, nil
+}
+
+// displayName returns the name of a resource as written in error messages.
+func displayName(name string) string {
+	return strings.ReplaceAll(name, "_", " ")
+}
+
+// decodeEnvelope decodes the value keyed by key in the JSON object body into
+// v, and reports whether the key was found.
+func decodeEnvelope(body []byte, key string, v any) (bool, error) {
+	envelope := map[string]json.RawMessage{}
+	err := json.Unmarshal(body, &envelope)
+	if err != nil {
+		return false, fmt.Errorf("error unmarshalling JSON: %w", err)
+	}
+
+	value, ok := envelope[key]
+	if !ok {
+		return false, nil
+	}
+
+	err = json.Unmarshal(value, v)
+	if err != nil {
+		return false, fmt.Errorf("error unmarshalling JSON: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,177 @@
+package mageai
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type restTestObject struct {
+	Name string `json:"name"`
+	UUID string `json:"uuid"`
+}
+
+var restTestResource = restResource[restTestObject]{
+	name:   "test_object",
+	plural: "test_objects",
+	id:     func(object *restTestObject) string { return object.UUID },
+}
+
+// restTestRequest is a request received by the server of newRESTTestServer.
+type restTestRequest struct {
+	method string
+	uri    string
+	body   string
+}
+
+// newRESTTestServer returns a client for a server that answers every request
+// with respBody, and the last request the server received.
+func newRESTTestServer(t *testing.T, respBody string) (*client, *restTestRequest) {
+	t.Helper()
+
+	received := &restTestRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*received = restTestRequest{method: r.Method, uri: r.URL.RequestURI(), body: string(body)}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(respBody))
+	}))
+	t.Cleanup(server.Close)
+	return newRetryTestClient(t, server.URL, 0), received
+}
+
+func TestRESTResourceEnvelopes(t *testing.T) {
+	c, received := newRESTTestServer(t, `{"test_object": {"name": "example", "uuid": "example_uuid"}, "metadata": {}}`)
+	ctx := context.Background()
+
+	testCases := map[string]struct {
+		call           func() (*restTestObject, error)
+		expectedMethod string
+		expectedURI    string
+		expectedBody   string
+	}{
+		"create": {
+			call: func() (*restTestObject, error) {
+				return restTestResource.create(ctx, c, "parents/parent/test_objects", restTestObject{Name: "example"})
+			},
+			expectedMethod: http.MethodPost,
+			expectedURI:    "/api/parents/parent/test_objects",
+			expectedBody:   `{"test_object":{"name":"example","uuid":""}}`,
+		},
+		"read": {
+			call: func() (*restTestObject, error) {
+				return restTestResource.read(ctx, c, "test_objects/example_uuid", url.Values{"include": []string{"blocks"}})
+			},
+			expectedMethod: http.MethodGet,
+			expectedURI:    "/api/test_objects/example_uuid?include=blocks",
+		},
+		"update": {
+			call: func() (*restTestObject, error) {
+				return restTestResource.update(ctx, c, "test_objects/example_uuid", restTestObject{Name: "example"})
+			},
+			expectedMethod: http.MethodPut,
+			expectedURI:    "/api/test_objects/example_uuid",
+			expectedBody:   `{"test_object":{"name":"example","uuid":""}}`,
+		},
+		"delete": {
+			call: func() (*restTestObject, error) {
+				return restTestResource.delete(ctx, c, "test_objects/example_uuid")
+			},
+			expectedMethod: http.MethodDelete,
+			expectedURI:    "/api/test_objects/example_uuid",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			object, err := testCase.call()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if object.UUID != "example_uuid" || object.Name != "example" {
+				t.Errorf("unexpected object: %+v", object)
+			}
+
+			if received.method != testCase.expectedMethod || received.uri != testCase.expectedURI {
+				t.Errorf("expected %s %s, got %s %s", testCase.expectedMethod, testCase.expectedURI, received.method, received.uri)
+			}
+
+			if received.body != testCase.expectedBody {
+				t.Errorf("expected body %q, got %q", testCase.expectedBody, received.body)
+			}
+		})
+	}
+}
+
+func TestRESTResourceUnexpectedResponse(t *testing.T) {
+	testCases := map[string]string{
+		"missing envelope": `{"other_object": {"uuid": "example_uuid"}}`,
+		"missing id":       `{"test_object": {"name": "example"}}`,
+		"null object":      `{"test_object": null}`,
+	}
+
+	for name, respBody := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c, _ := newRESTTestServer(t, respBody)
+
+			_, err := restTestResource.update(context.Background(), c, "test_objects/example_uuid", restTestObject{})
+			if err == nil || !strings.Contains(err.Error(), "error updating test object: unexpected response") {
+				t.Errorf("expected an unexpected response error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRESTResourceList(t *testing.T) {
+	testCases := map[string]struct {
+		respBody      string
+		expectedCount int
+		expectErr     bool
+	}{
+		"objects": {
+			respBody:      `{"test_objects": [{"uuid": "first"}, {"uuid": "second"}], "metadata": {"count": 2}}`,
+			expectedCount: 2,
+		},
+		"empty": {
+			respBody: `{"test_objects": []}`,
+		},
+		"null": {
+			respBody: `{"test_objects": null}`,
+		},
+		"missing envelope": {
+			respBody:  `{}`,
+			expectErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c, received := newRESTTestServer(t, testCase.respBody)
+
+			objects, err := restTestResource.list(context.Background(), c, "test_objects", url.Values{"type": []string{"python"}})
+			if testCase.expectErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if objects == nil || len(objects) != testCase.expectedCount {
+				t.Errorf("expected %d objects, got %v", testCase.expectedCount, objects)
+			}
+
+			if received.uri != "/api/test_objects?type=python" {
+				t.Errorf("unexpected request URI: %s", received.uri)
+			}
+		})
+	}
+}
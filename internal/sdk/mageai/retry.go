@@ -0,0 +1,98 @@
+package mageai
+
+import (
+	"crypto/tls"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryWaitMax = 30 * time.Second
+	defaultRetryWaitMin = 1 * time.Second
+)
+
+// shouldRetry reports whether a failed request can be sent again. Requests
+// that were rejected before reaching Mage AI are retried for every method,
+// other failures only for idempotent methods.
+func shouldRetry(httpMethod string, err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			return true
+		case http.StatusBadGateway, http.StatusGatewayTimeout:
+			return isIdempotent(httpMethod)
+		}
+		return false
+	}
+
+	var contextErr *ContextError
+	if errors.As(err, &contextErr) {
+		return false
+	}
+
+	// A certificate rejected by either side is rejected again on every attempt
+	var certErr *tls.CertificateVerificationError
+	if errors.As(err, &certErr) {
+		return false
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		switch opErr.Op {
+		case "dial":
+			return true
+		case "remote error":
+			return false
+		}
+	}
+	return isIdempotent(httpMethod)
+}
+
+func isIdempotent(httpMethod string) bool {
+	switch httpMethod {
+	case http.MethodDelete, http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut:
+		return true
+	}
+	return false
+}
+
+// retryWait returns how long to wait before the given retry attempt, starting
+// at 0. The Retry-After header of the response takes precedence over the
+// exponential backoff, both are capped at RetryWaitMax.
+func (c *client) retryWait(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(wait, c.config.RetryWaitMax)
+		}
+	}
+
+	wait := c.config.RetryWaitMin << attempt
+	if wait <= 0 || wait > c.config.RetryWaitMax {
+		wait = c.config.RetryWaitMax
+	}
+
+	// Add jitter so that clients failing together do not retry together
+	return wait/2 + rand.N(wait/2+1)
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(retryAfter string) (time.Duration, bool) {
+	if retryAfter == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+
+	if date, err := http.ParseTime(retryAfter); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,228 @@
+package mageai
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryTestClient(t *testing.T, host string, maxRetries int) *client {
+	t.Helper()
+
+	c, err := New(&ClientConfig{
+		Host:         host,
+		ApiKey:       "test",
+		MaxRetries:   maxRetries,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	t.Cleanup(c.Close)
+	return c.(*client)
+}
+
+// newFlakyServer returns a server that answers the first failures requests
+// with statusCode and the following ones with an empty pipeline list.
+func newFlakyServer(t *testing.T, failures int32, statusCode int, header http.Header) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(statusCode)
+			return
+		}
+		_, _ = w.Write([]byte(`{"pipelines": []}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestMakeAPICallRetries(t *testing.T) {
+	testCases := map[string]struct {
+		method           string
+		statusCode       int
+		maxRetries       int
+		expectedRequests int32
+		expectError      bool
+	}{
+		"get retried on bad gateway": {
+			method:           http.MethodGet,
+			statusCode:       http.StatusBadGateway,
+			maxRetries:       3,
+			expectedRequests: 3,
+		},
+		"post retried on service unavailable": {
+			method:           http.MethodPost,
+			statusCode:       http.StatusServiceUnavailable,
+			maxRetries:       3,
+			expectedRequests: 3,
+		},
+		"post not retried on bad gateway": {
+			method:           http.MethodPost,
+			statusCode:       http.StatusBadGateway,
+			maxRetries:       3,
+			expectedRequests: 1,
+			expectError:      true,
+		},
+		"get not retried on internal server error": {
+			method:           http.MethodGet,
+			statusCode:       http.StatusInternalServerError,
+			maxRetries:       3,
+			expectedRequests: 1,
+			expectError:      true,
+		},
+		"get gives up after max retries": {
+			method:           http.MethodGet,
+			statusCode:       http.StatusServiceUnavailable,
+			maxRetries:       1,
+			expectedRequests: 2,
+			expectError:      true,
+		},
+		"retries disabled": {
+			method:           http.MethodGet,
+			statusCode:       http.StatusServiceUnavailable,
+			maxRetries:       0,
+			expectedRequests: 1,
+			expectError:      true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server, requests := newFlakyServer(t, 2, testCase.statusCode, nil)
+			c := newRetryTestClient(t, server.URL, testCase.maxRetries)
+
+			_, err := c.makeAPICall(context.Background(), testCase.method, PipelinesAPIPath, nil)
+			if testCase.expectError && err == nil {
+				t.Error("expected an error, got nil")
+			}
+
+			if !testCase.expectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if got := requests.Load(); got != testCase.expectedRequests {
+				t.Errorf("expected %d requests, got %d", testCase.expectedRequests, got)
+			}
+		})
+	}
+}
+
+func TestMakeAPICallRetriesResendBody(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"pipeline":{"name":"example_pipeline","type":"python"}}` {
+			t.Errorf("unexpected request body: %s", body)
+		}
+
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"pipeline": {"uuid": "example_pipeline"}}`))
+	}))
+	defer server.Close()
+	c := newRetryTestClient(t, server.URL, 1)
+
+	_, err := c.CreatePipeline(context.Background(), &CreatePipelineRequest{Pipeline: PipelineRequest{Name: "example_pipeline", Type: pythonPipelineType}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMakeAPICallRetriesConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	host := "http://" + listener.Addr().String()
+	listener.Close()
+
+	c := newRetryTestClient(t, host, 2)
+	start := time.Now()
+	_, err = c.makeAPICall(context.Background(), http.MethodPost, PipelinesAPIPath, nil)
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	if !shouldRetry(http.MethodPost, err) {
+		t.Errorf("expected a connection refused error to be retryable, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Millisecond {
+		t.Errorf("expected the client to wait between retries, took %s", elapsed)
+	}
+}
+
+func TestMakeAPICallRetryCanceledWhileWaiting(t *testing.T) {
+	server, requests := newFlakyServer(t, 1, http.StatusServiceUnavailable, http.Header{"Retry-After": []string{"30"}})
+	c := newRetryTestClient(t, server.URL, 3)
+	c.config.RetryWaitMax = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.makeAPICall(ctx, http.MethodGet, PipelinesAPIPath, nil)
+	if !IsTimeout(err) {
+		t.Errorf("expected IsTimeout to be true for %v", err)
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	c := &client{config: ClientConfig{RetryWaitMin: time.Second, RetryWaitMax: 10 * time.Second}}
+
+	for attempt, expectedMax := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		wait := c.retryWait(attempt, nil)
+		if wait < expectedMax/2 || wait > expectedMax {
+			t.Errorf("attempt %d: expected a wait between %s and %s, got %s", attempt, expectedMax/2, expectedMax, wait)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	if wait := c.retryWait(0, resp); wait != 3*time.Second {
+		t.Errorf("expected Retry-After to be honored, got %s", wait)
+	}
+
+	resp = &http.Response{Header: http.Header{"Retry-After": []string{"120"}}}
+	if wait := c.retryWait(0, resp); wait != 10*time.Second {
+		t.Errorf("expected Retry-After to be capped at RetryWaitMax, got %s", wait)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	testCases := map[string]struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		"empty":       {value: "", ok: false},
+		"seconds":     {value: "5", expected: 5 * time.Second, ok: true},
+		"past date":   {value: "Wed, 21 Oct 2015 07:28:00 GMT", expected: 0, ok: true},
+		"not a value": {value: "soon", ok: false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := parseRetryAfter(testCase.value)
+			if ok != testCase.ok || got != testCase.expected {
+				t.Errorf("expected (%s, %t), got (%s, %t)", testCase.expected, testCase.ok, got, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package mageai
+
+import (
+	"context"
+	"fmt"
+	"path"
+)
+
+const (
+	SecretsAPIPath = "secrets"
+)
+
+type SecretAPI interface {
+	CreateSecret(ctx context.Context, secretRequest *CreateSecretRequest) (*secretResponse, error)
+	DeleteSecret(ctx context.Context, name *string) error
+	ReadSecret(ctx context.Context, name *string) (*Secret, error)
+	ReadSecrets(ctx context.Context) (*secretsResponse, error)
+}
+
+var secretResource = restResource[Secret]{
+	name:   "secret",
+	plural: "secrets",
+	id:     func(secret *Secret) string { return secret.Name },
+}
+
+type secretResponse struct {
+	Secret Secret `json:"secret"`
+}
+
+type secretsResponse struct {
+	Secrets []Secret `json:"secrets"`
+}
+
+type CreateSecretRequest struct {
+	Secret SecretRequest `json:"secret"`
+}
+
+type SecretRequest struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (c *client) CreateSecret(ctx context.Context, secretRequest *CreateSecretRequest) (*secretResponse, error) {
+	secret, err := secretResource.create(ctx, c, SecretsAPIPath, secretRequest.Secret)
+	if err != nil {
+		return nil, err
+	}
+	return &secretResponse{Secret: *secret}, nil
+}
+
+func (c *client) DeleteSecret(ctx context.Context, name *string) error {
+	_, err := secretResource.delete(ctx, c, path.Join(SecretsAPIPath, *name))
+	return err
+}
+
+// ReadSecret returns the secret with the given name. Mage AI never returns
+// the value of a secret and has no endpoint for a single secret, so only its
+// existence is looked up in the list of secrets.
+func (c *client) ReadSecret(ctx context.Context, name *string) (*Secret, error) {
+	readSecretsResponse, err := c.ReadSecrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, secret := range readSecretsResponse.Secrets {
+		if secret.Name == *name {
+			return &secret, nil
+		}
+	}
+	return nil, fmt.Errorf("secret %s does not exist: %w", *name, ErrNotFound)
+}
+
+func (c *client) ReadSecrets(ctx context.Context) (*secretsResponse, error) {
+	secrets, err := secretResource.list(ctx, c, SecretsAPIPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &secretsResponse{Secrets: secrets}, nil
+}
@@ -0,0 +1,138 @@
+package mageai
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var serverInfoResource = restResource[ServerInfo]{
+	name:   "project",
+	plural: "projects",
+	id:     func(info *ServerInfo) string { return info.Version },
+}
+
+// ServerInfo describes the Mage AI server and its active project, as returned
+// by the projects endpoint.
+type ServerInfo struct {
+	LatestVersion string `json:"latest_version"`
+	Name          string `json:"name"`
+	ProjectType   string `json:"project_type"`
+	ProjectUUID   string `json:"project_uuid"`
+	Version       string `json:"version"`
+}
+
+// AtLeast reports whether the version of the server is the given version or a
+// later one. It also reports true when the version of the server cannot be
+// parsed, e.g. for development builds, since it is then unknown whether the
+// server is older.
+func (i *ServerInfo) AtLeast(version string) bool {
+	serverVersion, ok := parseVersion(i.Version)
+	if !ok {
+		return true
+	}
+
+	minVersion, ok := parseVersion(version)
+	if !ok {
+		return true
+	}
+
+	for n := range max(len(serverVersion), len(minVersion)) {
+		serverPart, minPart := versionPart(serverVersion, n), versionPart(minVersion, n)
+		if serverPart != minPart {
+			return serverPart > minPart
+		}
+	}
+	return true
+}
+
+// parseVersion parses the numeric parts of a version such as 0.9.76. The
+// suffix of a part, e.g. rc1 in 0.9.76rc1, is ignored.
+func parseVersion(version string) ([]int, bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if version == "" {
+		return nil, false
+	}
+
+	parts := []int{}
+	for _, part := range strings.Split(version, ".") {
+		digits := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' })
+		if digits >= 0 {
+			part = part[:digits]
+		}
+
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+		parts = append(parts, n)
+
+		// A suffix ends the version, e.g. 0.9.76rc1.post2
+		if digits >= 0 {
+			break
+		}
+	}
+	return parts, true
+}
+
+func versionPart(version []int, n int) int {
+	if n < len(version) {
+		return version[n]
+	}
+	return 0
+}
+
+// serverInfoCache holds the server info of each project once it was read. It
+// is shared by the clients of all the projects of a server.
+type serverInfoCache struct {
+	mu    sync.Mutex
+	infos map[string]*ServerInfo
+}
+
+// ServerInfo returns the description of the server and the project of the
+// client. It is read once per project, failures are not cached.
+//
+// The projects endpoint is read rather than the status one, which does not
+// return the version of Mage AI.
+func (c *client) ServerInfo(ctx context.Context) (*ServerInfo, error) {
+	c.serverInfo.mu.Lock()
+	defer c.serverInfo.mu.Unlock()
+
+	if cached, ok := c.serverInfo.infos[c.config.Project]; ok {
+		info := *cached
+		return &info, nil
+	}
+
+	projects, err := serverInfoResource.list(ctx, c, ProjectsAPIPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	info, ok := activeProject(projects, c.config.Project)
+	if !ok {
+		return nil, fmt.Errorf("error getting server info: no project returned")
+	}
+
+	if c.serverInfo.infos == nil {
+		c.serverInfo.infos = map[string]*ServerInfo{}
+	}
+	c.serverInfo.infos[c.config.Project] = &info
+	return &info, nil
+}
+
+// activeProject returns the project of the client among the listed projects,
+// falling back to the first one, which is the active project of Mage AI.
+func activeProject(projects []ServerInfo, project string) (ServerInfo, bool) {
+	for _, info := range projects {
+		if project != "" && info.Name == project {
+			return info, true
+		}
+	}
+
+	if len(projects) == 0 {
+		return ServerInfo{}, false
+	}
+	return projects[0], true
+}
@@ -0,0 +1,116 @@
+package mageai_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func TestServerInfoAtLeast(t *testing.T) {
+	testCases := map[string]struct {
+		serverVersion string
+		minVersion    string
+		expected      bool
+	}{
+		"same":              {serverVersion: "0.9.76", minVersion: "0.9.76", expected: true},
+		"newer patch":       {serverVersion: "0.9.76", minVersion: "0.9.4", expected: true},
+		"older patch":       {serverVersion: "0.9.3", minVersion: "0.9.4", expected: false},
+		"older minor":       {serverVersion: "0.8.99", minVersion: "0.9.0", expected: false},
+		"shorter":           {serverVersion: "0.9", minVersion: "0.9.0", expected: true},
+		"prefix and suffix": {serverVersion: "v0.9.76rc1", minVersion: "0.9.76", expected: true},
+		"unknown server":    {serverVersion: "", minVersion: "0.9.0", expected: true},
+		"development build": {serverVersion: "dev", minVersion: "0.9.0", expected: true},
+		"unparsable min":    {serverVersion: "0.8.0", minVersion: "latest", expected: true},
+		"newer major":       {serverVersion: "1.0.0", minVersion: "0.9.76", expected: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			info := mageai.ServerInfo{Version: testCase.serverVersion}
+			if atLeast := info.AtLeast(testCase.minVersion); atLeast != testCase.expected {
+				t.Errorf("expected %q at least %q to be %t", testCase.serverVersion, testCase.minVersion, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestServerInfoCached(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.SetVersion("0.9.50")
+	client := newProjectTestClient(t, server, "")
+
+	for range 2 {
+		info, err := client.ServerInfo(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if info.Version != "0.9.50" || info.Name != "mageaitest" || info.ProjectType != "standalone" {
+			t.Errorf("unexpected server info: %+v", info)
+		}
+	}
+
+	if reads := server.ServerInfoReads(); reads != 1 {
+		t.Errorf("expected the server info to be read once, got %d", reads)
+	}
+}
+
+func TestServerInfoOfProject(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.AddProjects("sales")
+	client := newProjectTestClient(t, server, "")
+
+	info, err := client.WithProject("sales").ServerInfo(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if info.Name != "sales" || info.ProjectType != "sub" {
+		t.Errorf("expected the server info of the project, got %+v", info)
+	}
+}
+
+func TestServerInfoOfListedProject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"projects": [{"name": "main", "version": "0.9.76"}, {"name": "sales", "version": "0.9.76"}], "project": {}}`))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+
+	info, err := client.WithProject("sales").ServerInfo(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if info.Name != "sales" {
+		t.Errorf("expected the server info of the project, got %+v", info)
+	}
+}
+
+func TestServerInfoCachedPerProject(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.AddProjects("sales")
+	client := newProjectTestClient(t, server, "")
+
+	expected := map[string]string{"": "mageaitest", "sales": "sales"}
+	for range 2 {
+		for project, name := range expected {
+			info, err := client.WithProject(project).ServerInfo(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if info.Name != name {
+				t.Errorf("expected the server info of project %q, got %+v", project, info)
+			}
+		}
+	}
+
+	if reads := server.ServerInfoReads(); reads != 2 {
+		t.Errorf("expected the server info to be read once per project, got %d", reads)
+	}
+}
@@ -0,0 +1,137 @@
+package mageai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	SessionsAPIPath = "sessions"
+
+	// sessionExpiryMargin is how long before its expiry a session token is
+	// refreshed, so that it does not expire while a request is in flight.
+	sessionExpiryMargin = time.Minute
+)
+
+type sessionResponse struct {
+	Session Session `json:"session"`
+}
+
+type Session struct {
+	Expires any    `json:"expires"`
+	Token   string `json:"token"`
+}
+
+type CreateSessionRequest struct {
+	Session SessionRequest `json:"session"`
+}
+
+type SessionRequest struct {
+	Email    string `json:"email,omitempty"`
+	Password string `json:"password"`
+	Username string `json:"username,omitempty"`
+}
+
+// session holds the OAuth token of the client when it authenticates with a
+// username and password.
+type session struct {
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func (c *client) usesSession() bool {
+	return c.config.Username != ""
+}
+
+// sessionToken returns the OAuth token to send with the requests, creating a
+// session on first use and when the previous one expired. It returns an empty
+// token when the client only authenticates with the API key.
+func (c *client) sessionToken(ctx context.Context) (string, error) {
+	if !c.usesSession() {
+		return "", nil
+	}
+
+	c.session.mu.Lock()
+	defer c.session.mu.Unlock()
+
+	if c.session.token != "" && (c.session.expiresAt.IsZero() || time.Now().Add(sessionExpiryMargin).Before(c.session.expiresAt)) {
+		return c.session.token, nil
+	}
+
+	newSession, err := c.createSession(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.session.token = newSession.Token
+	c.session.expiresAt = parseSessionExpiry(newSession.Expires)
+	return c.session.token, nil
+}
+
+// invalidateSessionToken drops the token rejected by Mage AI, unless it was
+// already replaced by a concurrent request.
+func (c *client) invalidateSessionToken(token string) {
+	c.session.mu.Lock()
+	defer c.session.mu.Unlock()
+
+	if c.session.token == token {
+		c.session.token = ""
+	}
+}
+
+func (c *client) createSession(ctx context.Context) (*Session, error) {
+	sessionRequest := SessionRequest{Password: c.config.Password}
+	if strings.Contains(c.config.Username, "@") {
+		sessionRequest.Email = c.config.Username
+	} else {
+		sessionRequest.Username = c.config.Username
+	}
+
+	reqBody, err := json.Marshal(CreateSessionRequest{Session: sessionRequest})
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, _, err := c.doAPICall(ctx, http.MethodPost, SessionsAPIPath, reqBody, "")
+	if err != nil {
+		return nil, fmt.Errorf("error creating session: %w", err)
+	}
+
+	createSessionResponse := sessionResponse{}
+	err = json.Unmarshal(respBody, &createSessionResponse)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
+	}
+
+	if createSessionResponse.Session.Token == "" {
+		return nil, fmt.Errorf("error creating session: unexpected response: %s", respBody)
+	}
+	return &createSessionResponse.Session, nil
+}
+
+// parseSessionExpiry parses the expiry of a session, sent by Mage AI either as
+// a timestamp or a date. It returns the zero time when the expiry is unknown,
+// in which case the token is only refreshed once Mage AI rejects it.
+func parseSessionExpiry(expires any) time.Time {
+	switch expires := expires.(type) {
+	case float64:
+		// Timestamps in milliseconds are too large to be in seconds
+		if expires > 1e12 {
+			return time.UnixMilli(int64(expires))
+		}
+		return time.Unix(int64(expires), 0)
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999-07:00", "2006-01-02 15:04:05.999999", "2006-01-02T15:04:05.999999"} {
+			if expiresAt, err := time.Parse(layout, expires); err == nil {
+				return expiresAt
+			}
+		}
+	}
+	return time.Time{}
+}
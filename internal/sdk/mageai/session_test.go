@@ -0,0 +1,127 @@
+package mageai_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func newSessionTestClient(t *testing.T, server *mageaitest.Server, username, password string) mageai.Client {
+	t.Helper()
+
+	client, err := mageai.New(&mageai.ClientConfig{
+		ApiKey:   mageaitest.APIKey,
+		Host:     server.URL,
+		Username: username,
+		Password: password,
+	})
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	t.Cleanup(client.Close)
+	return client
+}
+
+func readPipelines(t *testing.T, client mageai.Client) {
+	t.Helper()
+
+	if _, err := client.PipelineAPI().ReadPipelines(context.Background(), nil); err != nil {
+		t.Fatalf("reading pipelines: %v", err)
+	}
+}
+
+func TestSessionAuthentication(t *testing.T) {
+	testCases := map[string]string{
+		"username": "mage",
+		"email":    "mage@example.com",
+	}
+
+	for name, username := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := mageaitest.NewServer(t)
+			server.RequireUserAuthentication(username, "s3cr3t", time.Hour)
+			client := newSessionTestClient(t, server, username, "s3cr3t")
+
+			readPipelines(t, client)
+			readPipelines(t, client)
+
+			if created := server.SessionsCreated(); created != 1 {
+				t.Errorf("expected the session to be created once, got %d", created)
+			}
+		})
+	}
+}
+
+func TestSessionAuthenticationRequired(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.RequireUserAuthentication("mage", "s3cr3t", time.Hour)
+	client := newTestClient(t, server.URL)
+
+	_, err := client.PipelineAPI().ReadPipelines(context.Background(), nil)
+	if !mageai.IsUnauthorized(err) {
+		t.Errorf("expected an unauthorized error without a session, got %v", err)
+	}
+}
+
+func TestSessionInvalidCredentials(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.RequireUserAuthentication("mage", "s3cr3t", time.Hour)
+	client := newSessionTestClient(t, server, "mage", "invalid")
+
+	_, err := client.PipelineAPI().ReadPipelines(context.Background(), nil)
+	if !mageai.IsUnauthorized(err) {
+		t.Errorf("expected an unauthorized error, got %v", err)
+	}
+}
+
+func TestSessionRefreshedWhenRejected(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.RequireUserAuthentication("mage", "s3cr3t", time.Hour)
+	client := newSessionTestClient(t, server, "mage", "s3cr3t")
+
+	readPipelines(t, client)
+	server.ExpireSessions()
+	readPipelines(t, client)
+
+	if created := server.SessionsCreated(); created != 2 {
+		t.Errorf("expected the session to be created twice, got %d", created)
+	}
+}
+
+func TestSessionRefreshedBeforeExpiry(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	// The session expires within the refresh margin of the client
+	server.RequireUserAuthentication("mage", "s3cr3t", 30*time.Second)
+	client := newSessionTestClient(t, server, "mage", "s3cr3t")
+
+	readPipelines(t, client)
+	readPipelines(t, client)
+
+	if created := server.SessionsCreated(); created != 2 {
+		t.Errorf("expected the session to be refreshed before it expires, got %d sessions", created)
+	}
+}
+
+func TestSessionCreatedOnceForConcurrentRequests(t *testing.T) {
+	server := mageaitest.NewServer(t)
+	server.RequireUserAuthentication("mage", "s3cr3t", time.Hour)
+	client := newSessionTestClient(t, server, "mage", "s3cr3t")
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			readPipelines(t, client)
+		}()
+	}
+	wg.Wait()
+
+	if created := server.SessionsCreated(); created != 1 {
+		t.Errorf("expected the session to be created once, got %d", created)
+	}
+}
@@ -0,0 +1,135 @@
+package mageai_test
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai"
+	"github.com/komminarlabs/terraform-provider-mageai/internal/sdk/mageai/mageaitest"
+)
+
+func newTLSTestClient(t *testing.T, config mageai.ClientConfig) mageai.Client {
+	t.Helper()
+
+	config.ApiKey = mageaitest.APIKey
+	client, err := mageai.New(&config)
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	t.Cleanup(client.Close)
+	return client
+}
+
+func TestTLS(t *testing.T) {
+	server := mageaitest.NewTLSServer(t, nil)
+
+	testCases := map[string]struct {
+		config    mageai.ClientConfig
+		expectErr bool
+	}{
+		"ca certificate": {
+			config: mageai.ClientConfig{CACertPEM: server.CertificatePEM()},
+		},
+		"insecure skip verify": {
+			config: mageai.ClientConfig{InsecureSkipVerify: true},
+		},
+		"unknown authority": {
+			config:    mageai.ClientConfig{MaxRetries: 3},
+			expectErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			testCase.config.Host = server.URL
+			client := newTLSTestClient(t, testCase.config)
+
+			_, err := client.PipelineAPI().ReadPipelines(context.Background(), nil)
+			if !testCase.expectErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var certErr *tls.CertificateVerificationError
+			if !errors.As(err, &certErr) {
+				t.Errorf("expected a certificate verification error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTLSClientCertificate(t *testing.T) {
+	clientCAs, clientCert, clientKey := mageaitest.NewClientCertificate(t)
+	server := mageaitest.NewTLSServer(t, clientCAs)
+
+	t.Run("with client certificate", func(t *testing.T) {
+		client := newTLSTestClient(t, mageai.ClientConfig{
+			Host:          server.URL,
+			CACertPEM:     server.CertificatePEM(),
+			ClientCertPEM: clientCert,
+			ClientKeyPEM:  clientKey,
+		})
+
+		if _, err := client.PipelineAPI().ReadPipelines(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("without client certificate", func(t *testing.T) {
+		client := newTLSTestClient(t, mageai.ClientConfig{
+			Host:       server.URL,
+			CACertPEM:  server.CertificatePEM(),
+			MaxRetries: 3,
+		})
+
+		_, err := client.PipelineAPI().ReadPipelines(context.Background(), nil)
+		var opErr *net.OpError
+		if !errors.As(err, &opErr) || opErr.Op != "remote error" {
+			t.Errorf("expected the server to reject the handshake, got %v", err)
+		}
+	})
+}
+
+func TestNewInvalidTLSConfig(t *testing.T) {
+	_, clientCert, clientKey := mageaitest.NewClientCertificate(t)
+
+	testCases := map[string]mageai.ClientConfig{
+		"invalid ca certificate": {
+			CACertPEM: []byte("invalid"),
+		},
+		"client certificate without key": {
+			ClientCertPEM: clientCert,
+		},
+		"client key without certificate": {
+			ClientKeyPEM: clientKey,
+		},
+	}
+
+	for name, config := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config.Host = "https://localhost:6789"
+			if _, err := mageai.New(&config); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	server, _ := newBlockingServer(t)
+	client := newTLSTestClient(t, mageai.ClientConfig{
+		Host:           server.URL,
+		RequestTimeout: 50 * time.Millisecond,
+	})
+
+	_, err := client.PipelineAPI().ReadPipelines(context.Background(), nil)
+	if !mageai.IsTimeout(err) {
+		t.Errorf("expected IsTimeout to be true for %v", err)
+	}
+}
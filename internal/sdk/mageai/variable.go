@@ -0,0 +1,96 @@
+package mageai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+)
+
+const (
+	VariablesAPIPath = "variables"
+	// GlobalVariablesBlockUUID is the block UUID Mage AI lists the global
+	// variables of a pipeline under.
+	GlobalVariablesBlockUUID = "global"
+)
+
+type VariableAPI interface {
+	CreateVariable(ctx context.Context, pipelineUUID *string, variableRequest *CreateVariableRequest) error
+	DeleteVariable(ctx context.Context, pipelineUUID *string, name *string) error
+	ReadVariable(ctx context.Context, pipelineUUID *string, name *string) (*Variable, error)
+	ReadVariables(ctx context.Context, pipelineUUID *string) (*variablesResponse, error)
+	UpdateVariable(ctx context.Context, pipelineUUID *string, name *string, variableRequest *UpdateVariableRequest) error
+}
+
+// variableResource lists the variables of a pipeline, grouped by block.
+var variableResource = restResource[BlockVariables]{
+	name:   "variable",
+	plural: "variables",
+	id:     func(blockVariables *BlockVariables) string { return blockVariables.Block.UUID },
+}
+
+type variablesResponse struct {
+	Variables []BlockVariables `json:"variables"`
+}
+
+type CreateVariableRequest struct {
+	Variable VariableRequest `json:"variable"`
+}
+
+type UpdateVariableRequest struct {
+	Variable VariableRequest `json:"variable"`
+}
+
+type VariableRequest struct {
+	Name  string `json:"name"`
+	Value any    `json:"value"`
+}
+
+// CreateVariable creates a global variable of the pipeline. Mage AI answers
+// with the variables of the pipeline, which are not returned.
+func (c *client) CreateVariable(ctx context.Context, pipelineUUID *string, variableRequest *CreateVariableRequest) error {
+	_, err := doJSON[CreateVariableRequest, json.RawMessage](ctx, c, http.MethodPost, path.Join(PipelinesAPIPath, *pipelineUUID, VariablesAPIPath), nil, variableRequest)
+	return err
+}
+
+func (c *client) DeleteVariable(ctx context.Context, pipelineUUID *string, name *string) error {
+	_, err := doJSON[any, json.RawMessage](ctx, c, http.MethodDelete, path.Join(PipelinesAPIPath, *pipelineUUID, VariablesAPIPath, *name), nil, nil)
+	return err
+}
+
+// ReadVariable returns the global variable of the pipeline with the given
+// name. Mage AI has no endpoint for a single variable, so it is looked up in
+// the variables of the pipeline.
+func (c *client) ReadVariable(ctx context.Context, pipelineUUID *string, name *string) (*Variable, error) {
+	readVariablesResponse, err := c.ReadVariables(ctx, pipelineUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, blockVariables := range readVariablesResponse.Variables {
+		if blockVariables.Block.UUID != GlobalVariablesBlockUUID {
+			continue
+		}
+
+		for _, variable := range blockVariables.Variables {
+			if variable.UUID == *name {
+				return &variable, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("variable %s does not exist in pipeline %s: %w", *name, *pipelineUUID, ErrNotFound)
+}
+
+func (c *client) ReadVariables(ctx context.Context, pipelineUUID *string) (*variablesResponse, error) {
+	variables, err := variableResource.list(ctx, c, path.Join(PipelinesAPIPath, *pipelineUUID, VariablesAPIPath), nil)
+	if err != nil {
+		return nil, err
+	}
+	return &variablesResponse{Variables: variables}, nil
+}
+
+func (c *client) UpdateVariable(ctx context.Context, pipelineUUID *string, name *string, variableRequest *UpdateVariableRequest) error {
+	_, err := doJSON[UpdateVariableRequest, json.RawMessage](ctx, c, http.MethodPut, path.Join(PipelinesAPIPath, *pipelineUUID, VariablesAPIPath, *name), nil, variableRequest)
+	return err
+}